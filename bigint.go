@@ -0,0 +1,156 @@
+package randutils
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// primeRounds is the number of Miller-Rabin rounds used by Prime and SafePrime,
+// in addition to the Baillie-PSW test that big.Int.ProbablyPrime always runs.
+const primeRounds = 20
+
+// smallPrimes are used to discard most safe prime candidates before the
+// expensive primality tests.
+var smallPrimes = []uint64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89, 97}
+
+// BigInt returns a random *big.Int in the range [0, max) (min inclusive, max exclusive).
+// It returns an error if max is nil or max <= 0, or if the source fails.
+func (g *Generator) BigInt(max *big.Int) (*big.Int, error) {
+	if max == nil || max.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid max: %v", max)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.bigIntnLocked(max)
+}
+
+// bigIntnLocked returns a uniform random integer in [0, n) for n > 0, read
+// from the buffered source with rejection sampling.
+// The caller must hold g.mu.
+func (g *Generator) bigIntnLocked(n *big.Int) (*big.Int, error) {
+	if n.IsUint64() {
+		x, err := g.uint64nLocked(n.Uint64())
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(x), nil
+	}
+	// Draw bitLen random bits and retry if the result is not below n, which
+	// happens with probability below 1/2.
+	bitLen := n.BitLen()
+	buf := make([]byte, (bitLen+63)/64*8)
+	x := new(big.Int)
+	for {
+		for i := 0; i < len(buf); i += 8 {
+			w, err := g.uint64Locked()
+			if err != nil {
+				return nil, err
+			}
+			binary.BigEndian.PutUint64(buf[i:], w)
+		}
+		x.SetBytes(buf)
+		x.Rsh(x, uint(len(buf)*8-bitLen))
+		if x.Cmp(n) < 0 {
+			return x, nil
+		}
+	}
+}
+
+// BigIntRange returns a random *big.Int in the range [min, max) (min inclusive, max exclusive).
+// It returns an error if either bound is nil or min >= max.
+func (g *Generator) BigIntRange(min, max *big.Int) (*big.Int, error) {
+	if min == nil || max == nil || min.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("invalid range: min %v >= max %v", min, max)
+	}
+	result, err := g.BigInt(new(big.Int).Sub(max, min))
+	if err != nil {
+		return nil, err
+	}
+	return result.Add(result, min), nil
+}
+
+// BigIntBits returns a random number of exactly bits bits, i.e. with the top bit set.
+// The result is uniform in [2^(bits-1), 2^bits). It returns an error if bits < 1.
+func (g *Generator) BigIntBits(bits int) (*big.Int, error) {
+	if bits < 1 {
+		return nil, fmt.Errorf("invalid bits: %d", bits)
+	}
+	low := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	result, err := g.BigInt(low)
+	if err != nil {
+		return nil, err
+	}
+	return result.Or(result, low), nil
+}
+
+// BigIntOdd returns a random odd number of exactly bits bits.
+// It returns an error if bits < 1.
+func (g *Generator) BigIntOdd(bits int) (*big.Int, error) {
+	result, err := g.BigIntBits(bits)
+	if err != nil {
+		return nil, err
+	}
+	return result.SetBit(result, 0, 1), nil
+}
+
+// Prime returns a random probable prime of exactly bits bits.
+// Candidates are drawn from the generator's source, so a seeded Generator
+// yields reproducible primes. It returns an error if bits < 2.
+func (g *Generator) Prime(bits int) (*big.Int, error) {
+	if bits < 2 {
+		return nil, fmt.Errorf("invalid bits: %d", bits)
+	}
+	for {
+		p, err := g.BigIntOdd(bits)
+		if err != nil {
+			return nil, err
+		}
+		if bits == 2 || p.ProbablyPrime(primeRounds) {
+			// The only odd 2-bit number is the prime 3.
+			return p, nil
+		}
+	}
+}
+
+// SafePrime returns a random probable safe prime p = 2q + 1 of exactly bits bits,
+// where q is also a probable prime. It returns an error if bits < 3.
+// Because q is drawn as an odd number of bits-1 bits, the one safe prime with
+// an even q, 5, is never returned.
+// Safe primes are rare, so large sizes take considerably longer than Prime.
+func (g *Generator) SafePrime(bits int) (*big.Int, error) {
+	if bits < 3 {
+		return nil, fmt.Errorf("invalid bits: %d", bits)
+	}
+	p := new(big.Int)
+	for {
+		q, err := g.BigIntOdd(bits - 1)
+		if err != nil {
+			return nil, err
+		}
+		if !safeCandidate(q) || !q.ProbablyPrime(primeRounds) {
+			continue
+		}
+		p.Lsh(q, 1).SetBit(p, 0, 1)
+		if p.ProbablyPrime(primeRounds) {
+			return p, nil
+		}
+	}
+}
+
+// safeCandidate reports whether neither q nor 2q + 1 has a small prime factor,
+// unless it equals that prime.
+func safeCandidate(q *big.Int) bool {
+	r := new(big.Int)
+	for _, s := range smallPrimes {
+		m := r.Mod(q, r.SetUint64(s)).Uint64()
+		if m == 0 && (q.BitLen() > 7 || q.Uint64() != s) {
+			return false
+		}
+		// 2q + 1 is divisible by s exactly when q = (s - 1) / 2 mod s.
+		if m == (s-1)/2 && (q.BitLen() > 6 || 2*q.Uint64()+1 != s) {
+			return false
+		}
+	}
+	return true
+}
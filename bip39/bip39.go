@@ -0,0 +1,173 @@
+// Package bip39 implements BIP-39 mnemonic codes for deterministic wallets:
+// random entropy is encoded as 12 to 24 words with a SHA-256 checksum, and a
+// mnemonic plus an optional passphrase is stretched into a 64-byte binary
+// seed with PBKDF2-HMAC-SHA512.
+//
+// All nine official wordlists are embedded; functions taking a *Wordlist use
+// English when it is nil. Entropy is drawn from a randutils.Generator, so
+// wallets can be generated reproducibly in tests:
+//
+//	mnemonic, err := bip39.NewMnemonic(nil, 256, bip39.English)
+//	if err != nil {
+//		return err
+//	}
+//	seed, err := bip39.Seed(mnemonic, "passphrase", bip39.English)
+//
+// BIP-39 requires mnemonics and passphrases to be in Unicode normalization
+// form NFKD. The words of every embedded list are NFKD, and mnemonics are
+// joined with the list's separator: a space, or U+3000 IDEOGRAPHIC SPACE for
+// Japanese, which NFKD would map to a space. Seed joins words with a space
+// before hashing, so either form derives the standard seed. The package does
+// not normalize its input: a non-ASCII passphrase must be converted to NFKD
+// by the caller (for example with golang.org/x/text/unicode/norm), or the
+// derived seed will not match other implementations.
+package bip39
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+
+	"github.com/chaosoffire/go-randutils"
+)
+
+const (
+	// MinEntropyBits is the smallest entropy size, giving 12 words.
+	MinEntropyBits = 128
+	// MaxEntropyBits is the largest entropy size, giving 24 words.
+	MaxEntropyBits = 256
+
+	// bitsPerWord is the number of bits encoded by each word.
+	bitsPerWord = 11
+	// seedIterations and seedSize are the PBKDF2 parameters of Seed.
+	seedIterations = 2048
+	seedSize       = 64
+)
+
+// generator returns g, or the package default generator when g is nil.
+func generator(g *randutils.Generator) *randutils.Generator {
+	if g == nil {
+		return randutils.Default()
+	}
+	return g
+}
+
+// checkEntropyBits returns an error unless bits is a valid entropy size:
+// a multiple of 32 from MinEntropyBits to MaxEntropyBits.
+func checkEntropyBits(bits int) error {
+	if bits < MinEntropyBits || bits > MaxEntropyBits || bits%32 != 0 {
+		return fmt.Errorf("invalid entropy size: %d bits", bits)
+	}
+	return nil
+}
+
+// NewEntropy returns bits random bits read from g (nil selects randutils.Default()).
+// It returns an error if bits is not 128, 160, 192, 224 or 256, or the
+// generator's source fails.
+func NewEntropy(g *randutils.Generator, bits int) ([]byte, error) {
+	if err := checkEntropyBits(bits); err != nil {
+		return nil, err
+	}
+	return generator(g).Byte(bits / 8)
+}
+
+// NewMnemonic generates a mnemonic from bits random bits read from g
+// (nil selects randutils.Default()), with 3 words for every 32 bits:
+// 128 bits give 12 words and 256 bits give 24.
+// It returns an error if bits is not 128, 160, 192, 224 or 256, or the
+// generator's source fails.
+func NewMnemonic(g *randutils.Generator, bits int, wl *Wordlist) (string, error) {
+	entropy, err := NewEntropy(g, bits)
+	if err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy, wl)
+}
+
+// EntropyToMnemonic encodes entropy as a mnemonic from wl. The first
+// len(entropy)/4 bits of its SHA-256 hash are appended as a checksum, and
+// every 11 bits of the result select one word.
+// It returns an error if entropy is not 16, 20, 24, 28 or 32 bytes long.
+func EntropyToMnemonic(entropy []byte, wl *Wordlist) (string, error) {
+	if err := checkEntropyBits(len(entropy) * 8); err != nil {
+		return "", err
+	}
+	wl = orEnglish(wl)
+	hash := sha256.Sum256(entropy)
+	data := append(append(make([]byte, 0, len(entropy)+1), entropy...), hash[0])
+	n := len(entropy) * 3 / 4 // (bits + bits/32) / 11
+	words := make([]string, n)
+	for i := range words {
+		words[i] = wl.words[readBits(data, i*bitsPerWord)]
+	}
+	return strings.Join(words, wl.separator), nil
+}
+
+// readBits returns the 11-bit big-endian integer starting at bit offset off of data.
+func readBits(data []byte, off int) int {
+	v := 0
+	for b := off; b < off+bitsPerWord; b++ {
+		v = v<<1 | int(data[b/8]>>(7-b%8)&1)
+	}
+	return v
+}
+
+// MnemonicToEntropy decodes a mnemonic from wl and returns its entropy.
+// Words may be separated by any Unicode white space.
+// It returns an error if the mnemonic does not have 12, 15, 18, 21 or 24
+// words, a word is not in wl, or the checksum does not match.
+func MnemonicToEntropy(mnemonic string, wl *Wordlist) ([]byte, error) {
+	wl = orEnglish(wl)
+	words := strings.Fields(mnemonic)
+	bits := len(words) * bitsPerWord * 32 / 33
+	if len(words)%3 != 0 || checkEntropyBits(bits) != nil {
+		return nil, fmt.Errorf("invalid word count: %d", len(words))
+	}
+	data := make([]byte, bits/8+1)
+	for i, word := range words {
+		idx, ok := wl.index[word]
+		if !ok {
+			return nil, fmt.Errorf("word %d is not in the %s wordlist: %q", i+1, wl.name, word)
+		}
+		for b := range bitsPerWord {
+			if idx>>(bitsPerWord-1-b)&1 == 1 {
+				off := i*bitsPerWord + b
+				data[off/8] |= 1 << (7 - off%8)
+			}
+		}
+	}
+	entropy := data[:bits/8]
+	hash := sha256.Sum256(entropy)
+	checksumBits := bits / 32
+	if data[bits/8]>>(8-checksumBits) != hash[0]>>(8-checksumBits) {
+		return nil, fmt.Errorf("invalid mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// Validate returns an error describing why mnemonic is not a valid mnemonic
+// from wl, or nil if it is.
+func Validate(mnemonic string, wl *Wordlist) error {
+	_, err := MnemonicToEntropy(mnemonic, wl)
+	return err
+}
+
+// IsValid reports whether mnemonic is a valid mnemonic from wl.
+func IsValid(mnemonic string, wl *Wordlist) bool {
+	return Validate(mnemonic, wl) == nil
+}
+
+// Seed validates mnemonic against wl and derives its 64-byte binary seed:
+// PBKDF2-HMAC-SHA512 with 2048 iterations, the mnemonic as password and
+// "mnemonic" followed by passphrase as salt. The words are joined with
+// single spaces first, so any white space between them gives the same seed.
+// passphrase may be empty and must be in NFKD form.
+// It returns an error if the mnemonic is invalid.
+func Seed(mnemonic, passphrase string, wl *Wordlist) ([]byte, error) {
+	if err := Validate(mnemonic, wl); err != nil {
+		return nil, err
+	}
+	password := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2(sha512.New, []byte(password), []byte("mnemonic"+passphrase), seedIterations, seedSize), nil
+}
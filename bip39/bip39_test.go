@@ -0,0 +1,410 @@
+package bip39
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/chaosoffire/go-randutils"
+)
+
+// decodeHex decodes a hex test vector or fails the test
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// seeded returns a deterministic generator so the generation tests are reproducible
+func seeded() *randutils.Generator {
+	var seed randutils.Seed
+	for i := range seed {
+		seed[i] = byte(i * 7)
+	}
+	return randutils.NewSeeded(seed)
+}
+
+// trezorVectors are the English test vectors of the reference implementation
+// (https://github.com/trezor/python-mnemonic/blob/master/vectors.json),
+// whose seeds use the passphrase "TREZOR"
+var trezorVectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		entropy:  "00000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		entropy:  "80808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		seed:     "d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		seed:     "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+	{
+		entropy:  "000000000000000000000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon agent",
+		seed:     "035895f2f481b1b0f01fcf8c289c794660b289981a78f8106447707fdd9666ca06da5a9a565181599b79f53b844d8a71dd9f439c52a3d7b3e8a79c906ac845fa",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will",
+		seed:     "f2b94508732bcbacbcc020faefecfc89feafa6649a5491b8c952cede496c214a0c7b3c392d168748f2d4a612bada0753b52a1c7ac53c1e93abd5c6320b9e95dd",
+	},
+	{
+		entropy:  "808080808080808080808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+		seed:     "107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo when",
+		seed:     "0cd6e5d827bb62eb8fc1e262254223817fd068a74b5b449cc2f667c3f1f985a76379b43348d952e2265b4cd129090758b3e3c2c49103b5051aac2eaeb890a528",
+	},
+	{
+		entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+		mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+	},
+	{
+		entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		mnemonic: "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title",
+		seed:     "bc09fca1804f7e69da93c2f2028eb238c227f2e9dda30cd63699232578480a4021b146ad717fbb7e451ce9eb835f43620bf5c514db0f8add49f5d121449d3e87",
+	},
+	{
+		entropy:  "8080808080808080808080808080808080808080808080808080808080808080",
+		mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic bless",
+		seed:     "c0c519bd0e91a2ed54357d9d1ebef6f5af218a153624cf4f2da911a0ed8f7a09e2ef61af0aca007096df430022f7a2b6fb91661a9589097069720d015e4e982f",
+	},
+	{
+		entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		seed:     "dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+	},
+	{
+		entropy:  "77c2b00716cec7213839159e404db50d",
+		mnemonic: "jelly better achieve collect unaware mountain thought cargo oxygen act hood bridge",
+		seed:     "b5b6d0127db1a9d2226af0c3346031d77af31e918dba64287a1b44b8ebf63cdd52676f672a290aae502472cf2d602c051f3e6f18055e84e4c43897fc4e51a6ff",
+	},
+	{
+		entropy:  "b63a9c59a6e641f288ebc103017f1da9f8290b3da6bdef7b",
+		mnemonic: "renew stay biology evidence goat welcome casual join adapt armor shuffle fault little machine walk stumble urge swap",
+		seed:     "9248d83e06f4cd98debf5b6f010542760df925ce46cf38a1bdb4e4de7d21f5c39366941c69e1bdbf2966e0f6e6dbece898a0e2f0a4c2b3e640953dfe8b7bbdc5",
+	},
+	{
+		entropy:  "3e141609b97933b66a060dcddc71fad1d91677db872031e85f4c015c5e7e8982",
+		mnemonic: "dignity pass list indicate nasty swamp pool script soccer toe leaf photo multiply desk host tomato cradle drill spread actor shine dismiss champion exotic",
+		seed:     "ff7f3184df8696d8bef94b6c03114dbee0ef89ff938712301d27ed8336ca89ef9635da20af07d4175f2bf5f3de130f39c9d9e8dd0472489c19b1a020a940da67",
+	},
+	{
+		entropy:  "0460ef47585604c5660618db2e6a7e7f",
+		mnemonic: "afford alter spike radar gate glance object seek swamp infant panel yellow",
+		seed:     "65f93a9f36b6c85cbe634ffc1f99f2b82cbb10b31edc7f087b4f6cb9e976e9faf76ff41f8f27c99afdf38f7a303ba1136ee48a4c1e7fcd3dba7aa876113a36e4",
+	},
+	{
+		entropy:  "72f60ebac5dd8add8d2a25a797102c3ce21bc029c200076f",
+		mnemonic: "indicate race push merry suffer human cruise dwarf pole review arch keep canvas theme poem divorce alter left",
+		seed:     "3bbf9daa0dfad8229786ace5ddb4e00fa98a044ae4c4975ffd5e094dba9e0bb289349dbe2091761f30f382d4e35c4a670ee8ab50758d2c55881be69e327117ba",
+	},
+	{
+		entropy:  "2c85efc7f24ee4573d2b81a6ec66cee209b2dcbd09d8eddc51e0215b0b68e416",
+		mnemonic: "clutch control vehicle tonight unusual clog visa ice plunge glimpse recipe series open hour vintage deposit universe tip job dress radar refuse motion taste",
+		seed:     "fe908f96f46668b2d5b37d82f558c77ed0d69dd0e7e043a5b0511c48c2f1064694a956f86360c93dd04052a8899497ce9e985ebe0c8c52b955e6ae86d4ff4449",
+	},
+	{
+		entropy:  "eaebabb2383351fd31d703840b32e9e2",
+		mnemonic: "turtle front uncle idea crush write shrug there lottery flower risk shell",
+		seed:     "bdfb76a0759f301b0b899a1e3985227e53b3f51e67e3f2a65363caedf3e32fde42a66c404f18d7b05818c95ef3ca1e5146646856c461c073169467511680876c",
+	},
+	{
+		entropy:  "7ac45cfe7722ee6c7ba84fbc2d5bd61b45cb2fe5eb65aa78",
+		mnemonic: "kiss carry display unusual confirm curtain upgrade antique rotate hello void custom frequent obey nut hole price segment",
+		seed:     "ed56ff6c833c07982eb7119a8f48fd363c4a9b1601cd2de736b01045c5eb8ab4f57b079403485d1c4924f0790dc10a971763337cb9f9c62226f64fff26397c79",
+	},
+	{
+		entropy:  "4fa1a8bc3e6d80ee1316050e862c1812031493212b7ec3f3bb1b08f168cabeef",
+		mnemonic: "exile ask congress lamp submit jacket era scheme attend cousin alcohol catch course end lucky hurt sentence oven short ball bird grab wing top",
+		seed:     "095ee6f817b4c2cb30a5a797360a81a40ab0f9a4e25ecd672a3f58a0b5ba0687c096a6b14d2c0deb3bdefce4f61d01ae07417d502429352e27695163f7447a8c",
+	},
+	{
+		entropy:  "18ab19a9f54a9274f03e5209a2ac8a91",
+		mnemonic: "board flee heavy tunnel powder denial science ski answer betray cargo cat",
+		seed:     "6eff1bb21562918509c73cb990260db07c0ce34ff0e3cc4a8cb3276129fbcb300bddfe005831350efd633909f476c45c88253276d9fd0df6ef48609e8bb7dca8",
+	},
+	{
+		entropy:  "18a2e1d81b8ecfb2a333adcb0c17a5b9eb76cc5d05db91a4",
+		mnemonic: "board blade invite damage undo sun mimic interest slam gaze truly inherit resist great inject rocket museum chief",
+		seed:     "f84521c777a13b61564234bf8f8b62b3afce27fc4062b51bb5e62bdfecb23864ee6ecf07c1d5a97c0834307c5c852d8ceb88e7c97923c0a3b496bedd4e5f88a9",
+	},
+	{
+		entropy:  "15da872c95a13dd738fbf50e427583ad61f18fd99f628c417a61cf8343c90419",
+		mnemonic: "beyond stage sleep clip because twist token leaf atom beauty genius food business side grid unable middle armed observe pair crouch tonight away coconut",
+		seed:     "b15509eaa2d09d3efd3e006ef42151b30367dc6e3aa5e44caba3fe4d3e352e65101fbdb86a96776b91946ff06f8eac594dc6ee1d3e82a42dfe1b40fef6bcc3fd",
+	},
+}
+
+// TestTrezorVectors tests encoding, decoding and seed derivation against the reference vectors
+func TestTrezorVectors(t *testing.T) {
+	for _, tt := range trezorVectors {
+		entropy := decodeHex(t, tt.entropy)
+		mnemonic, err := EntropyToMnemonic(entropy, nil)
+		if err != nil {
+			t.Fatalf("EntropyToMnemonic(%s) error = %v", tt.entropy, err)
+		}
+		if mnemonic != tt.mnemonic {
+			t.Errorf("EntropyToMnemonic(%s) = %q, want %q", tt.entropy, mnemonic, tt.mnemonic)
+		}
+		got, err := MnemonicToEntropy(tt.mnemonic, English)
+		if err != nil {
+			t.Fatalf("MnemonicToEntropy(%q) error = %v", tt.mnemonic, err)
+		}
+		if !bytes.Equal(got, entropy) {
+			t.Errorf("MnemonicToEntropy(%q) = %x, want %s", tt.mnemonic, got, tt.entropy)
+		}
+		seed, err := Seed(tt.mnemonic, "TREZOR", English)
+		if err != nil {
+			t.Fatalf("Seed(%q) error = %v", tt.mnemonic, err)
+		}
+		if hex.EncodeToString(seed) != tt.seed {
+			t.Errorf("Seed(%q) = %x, want %s", tt.mnemonic, seed, tt.seed)
+		}
+	}
+}
+
+// TestSeed_Japanese tests the ideographic space separator and an NFKD passphrase
+// against the Japanese reference vector (https://github.com/bip32JP/bip32JP.github.io)
+func TestSeed_Japanese(t *testing.T) {
+	entropy := decodeHex(t, "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f")
+	// BIP-39 text is NFKD, so voiced kana are spelled with combining marks
+	want := "そつう\u3000れきた\u3099い\u3000ほんやく\u3000わかす\u3000りくつ\u3000" +
+		"は\u3099いか\u3000ろせん\u3000やちん\u3000そつう\u3000れきた\u3099い\u3000ほんやく\u3000わかめ"
+	mnemonic, err := EntropyToMnemonic(entropy, Japanese)
+	if err != nil {
+		t.Fatalf("EntropyToMnemonic() error = %v", err)
+	}
+	if mnemonic != want {
+		t.Errorf("EntropyToMnemonic() = %q, want %q", mnemonic, want)
+	}
+	// NFKD of "㍍ガバヴァぱばぐゞちぢ十人十色"
+	passphrase := "メートルカ\u3099ハ\u3099ウ\u3099ァは\u309aは\u3099く\u3099ゝ\u3099ちち\u3099十人十色"
+	seed, err := Seed(mnemonic, passphrase, Japanese)
+	if err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+	wantSeed := "aee025cbe6ca256862f889e48110a6a382365142f7d16f2b9545285b3af64e542143a577e9c144e101a6bdca18f8d97ec3366ebf5b088b1c1af9bc31346e60d9"
+	if hex.EncodeToString(seed) != wantSeed {
+		t.Errorf("Seed() = %x, want %s", seed, wantSeed)
+	}
+}
+
+// TestMnemonicToEntropy_Invalid tests that malformed mnemonics are rejected
+func TestMnemonicToEntropy_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		wl       *Wordlist
+		wantErr  string
+	}{
+		{"empty", "", nil, "invalid word count: 0"},
+		{"5 words", "dignity pass list indicate nasty", nil, "invalid word count: 5"},
+		{"11 words", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", nil, "invalid word count: 11"},
+		{"17 words", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", nil, "invalid word count: 17"},
+		{"27 words", strings.Repeat("zoo ", 27), nil, "invalid word count: 27"},
+		{"bad checksum 12", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon letter", nil, "invalid mnemonic checksum"},
+		{"unknown first word", "jello better achieve collect unaware mountain thought cargo oxygen act hood bridge", nil, "word 1 is not in the english wordlist"},
+		{"bad checksum 18", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo", nil, "invalid mnemonic checksum"},
+		{"bad checksum 24", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art art", nil, "invalid mnemonic checksum"},
+		{"unknown word", "letter advice cage absurd amount doctor acoustic avoid letter advice caged above", nil, "word 11 is not in the english wordlist"},
+		{"punctuation", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo, wrong", nil, "word 11 is not in the english wordlist"},
+		{"trailing period", "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always.", nil, "word 18 is not in the english wordlist"},
+		{"commas", "renew, stay, biology, evidence, goat, welcome, casual, join, adapt, armor, shuffle, fault, little, machine, walk, stumble, urge, swap", nil, "word 1 is not in the english wordlist"},
+		{"wrong wordlist", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", Spanish, "not in the spanish wordlist"},
+		{"uppercase", "Abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", nil, "word 1 is not in the english wordlist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MnemonicToEntropy(tt.mnemonic, tt.wl)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("MnemonicToEntropy() error = %v, want %q", err, tt.wantErr)
+			}
+			if IsValid(tt.mnemonic, tt.wl) {
+				t.Errorf("IsValid() = true, want false")
+			}
+			if _, err := Seed(tt.mnemonic, "", tt.wl); err == nil {
+				t.Errorf("Seed() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestSeed_Whitespace tests that the separators between words do not change the seed
+func TestSeed_Whitespace(t *testing.T) {
+	mnemonic := trezorVectors[0].mnemonic
+	want, err := Seed(mnemonic, "TREZOR", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spaced := "  " + strings.ReplaceAll(mnemonic, " ", " \t\n") + "\n"
+	got, err := Seed(spaced, "TREZOR", nil)
+	if err != nil {
+		t.Fatalf("Seed(%q) error = %v", spaced, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("Seed(%q) = %x, want %x", spaced, got, want)
+	}
+}
+
+// TestNewMnemonic tests generation for every entropy size and wordlist
+func TestNewMnemonic(t *testing.T) {
+	wordlists := []*Wordlist{English, ChineseSimplified, ChineseTraditional, Czech, French, Italian, Japanese, Korean, Spanish}
+	g := seeded()
+	for _, wl := range wordlists {
+		for bits := MinEntropyBits; bits <= MaxEntropyBits; bits += 32 {
+			mnemonic, err := NewMnemonic(g, bits, wl)
+			if err != nil {
+				t.Fatalf("NewMnemonic(%d, %s) error = %v", bits, wl.Name(), err)
+			}
+			words := strings.Split(mnemonic, wl.separator)
+			if len(words) != bits/32*3 {
+				t.Errorf("NewMnemonic(%d, %s) has %d words, want %d", bits, wl.Name(), len(words), bits/32*3)
+			}
+			entropy, err := MnemonicToEntropy(mnemonic, wl)
+			if err != nil {
+				t.Fatalf("MnemonicToEntropy(%q) error = %v", mnemonic, err)
+			}
+			if len(entropy) != bits/8 {
+				t.Errorf("MnemonicToEntropy(%q) returned %d bytes, want %d", mnemonic, len(entropy), bits/8)
+			}
+			again, err := EntropyToMnemonic(entropy, wl)
+			if err != nil || again != mnemonic {
+				t.Errorf("EntropyToMnemonic(%x) = %q, %v; want %q", entropy, again, err, mnemonic)
+			}
+		}
+	}
+}
+
+// TestNewMnemonic_Seeded tests that generation is reproducible from a seeded generator
+func TestNewMnemonic_Seeded(t *testing.T) {
+	a, err := NewMnemonic(seeded(), 256, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewMnemonic(seeded(), 256, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("NewMnemonic() with equal seeds = %q and %q", a, b)
+	}
+	c, err := NewMnemonic(nil, 256, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsValid(c, nil) || c == a {
+		t.Errorf("NewMnemonic(nil) = %q", c)
+	}
+}
+
+// TestNewEntropy_InvalidSize tests that unsupported entropy sizes are rejected
+func TestNewEntropy_InvalidSize(t *testing.T) {
+	for _, bits := range []int{-128, 0, 96, 127, 129, 144, 288, 512} {
+		if _, err := NewEntropy(nil, bits); err == nil {
+			t.Errorf("NewEntropy(%d) error = nil, want error", bits)
+		}
+		if _, err := NewMnemonic(nil, bits, nil); err == nil {
+			t.Errorf("NewMnemonic(%d) error = nil, want error", bits)
+		}
+	}
+	for _, size := range []int{0, 8, 15, 17, 33, 64} {
+		if _, err := EntropyToMnemonic(make([]byte, size), nil); err == nil {
+			t.Errorf("EntropyToMnemonic(%d bytes) error = nil, want error", size)
+		}
+	}
+}
+
+// TestWordlists tests the embedded wordlists against the checksums of the
+// files in the BIP-39 repository
+func TestWordlists(t *testing.T) {
+	tests := []struct {
+		wl    *Wordlist
+		data  string
+		crc   uint32
+		first string
+	}{
+		{English, englishWords, 0xc1dbd296, "abandon"},
+		{ChineseSimplified, chineseSimplifiedWords, 0xe3721bbf, "的"},
+		{ChineseTraditional, chineseTraditionalWords, 0x3c20b443, "的"},
+		{Czech, czechWords, 0xd1b5fda0, "abdikace"},
+		{French, frenchWords, 0x3e56b216, "abaisser"},
+		{Italian, italianWords, 0x2fc7d07e, "abaco"},
+		{Japanese, japaneseWords, 0x0acc1419, "あいこくしん"},
+		{Korean, koreanWords, 0x4ef461eb, "가격"},
+		{Spanish, spanishWords, 0x266e4f3d, "a\u0301baco"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wl.Name(), func(t *testing.T) {
+			if got := crc32.ChecksumIEEE([]byte(tt.data)); got != tt.crc {
+				t.Errorf("CRC-32 = %08x, want %08x", got, tt.crc)
+			}
+			if len(tt.wl.index) != wordCount {
+				t.Errorf("wordlist has %d distinct words, want %d", len(tt.wl.index), wordCount)
+			}
+			if got := tt.wl.Word(0); got != tt.first {
+				t.Errorf("Word(0) = %q, want %q", got, tt.first)
+			}
+			for i := range wordCount {
+				if j, ok := tt.wl.Index(tt.wl.Word(i)); !ok || j != i {
+					t.Fatalf("Index(Word(%d)) = %d, %v", i, j, ok)
+				}
+			}
+		})
+	}
+}
+
+// TestPBKDF2 tests the key derivation against PBKDF2-HMAC-SHA512 vectors
+// (the RFC 6070 inputs with HMAC-SHA512)
+func TestPBKDF2(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		iterations     int
+		want           string
+	}{
+		{"password", "salt", 1, "867f70cf1ade02cff3752599a3a53dc4af34c7a669815ae5d513554e1c8cf252c02d470a285a0501bad999bfe943c08f050235d7d68b1da55e63f73b60a57fce"},
+		{"password", "salt", 2, "e1d9c16aa681708a45f5c7c4e215ceb66e011a2e9f0040713f18aefdb866d53cf76cab2868a39b9f7840edce4fef5a82be67335c77a6068e04112754f27ccf4e"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, "8c0511f4c6e597c6ac6315d8f0362e225f3c501495ba23b868c005174dc4ee71115b59f9e60cd9532fa33e0f75aefe30225c583a186cd82bd4daea9724a3d3b804f75bdd41494fa324cab24bcc680fb3b96a30cf5d21fac3c2875913919f3399b1d9ce7e"},
+	}
+	for _, tt := range tests {
+		want := decodeHex(t, tt.want)
+		got := pbkdf2(sha512.New, []byte(tt.password), []byte(tt.salt), tt.iterations, len(want))
+		if !bytes.Equal(got, want) {
+			t.Errorf("pbkdf2(%q, %q, %d) = %x, want %x", tt.password, tt.salt, tt.iterations, got, want)
+		}
+	}
+}
+
+// BenchmarkSeed measures seed derivation, dominated by 2048 PBKDF2 iterations
+func BenchmarkSeed(b *testing.B) {
+	mnemonic := trezorVectors[len(trezorVectors)-1].mnemonic
+	for range b.N {
+		if _, err := Seed(mnemonic, "TREZOR", nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package bip39
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"hash"
+)
+
+// pbkdf2 derives a key of keyLen bytes from password and salt with PBKDF2
+// (RFC 8018, section 5.2) using HMAC over newHash as the pseudorandom function.
+func pbkdf2(newHash func() hash.Hash, password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(newHash, password)
+	size := prf.Size()
+	key := make([]byte, 0, (keyLen+size-1)/size*size)
+	var counter [4]byte
+	u := make([]byte, size)
+	t := make([]byte, size)
+	for block := uint32(1); len(key) < keyLen; block++ {
+		// T_i = U_1 ^ U_2 ^ ... ^ U_c with U_1 = PRF(P, S || INT(i))
+		// and U_j = PRF(P, U_{j-1}).
+		binary.BigEndian.PutUint32(counter[:], block)
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		u = prf.Sum(u[:0])
+		copy(t, u)
+		for range iterations - 1 {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for k := range t {
+				t[k] ^= u[k]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
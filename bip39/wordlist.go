@@ -0,0 +1,104 @@
+package bip39
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// The official BIP-39 wordlists from
+// https://github.com/bitcoin/bips/tree/master/bip-0039, stored byte for byte.
+// Every word is already in Unicode normalization form NFKD.
+var (
+	//go:embed wordlists/english.txt
+	englishWords string
+	//go:embed wordlists/chinese_simplified.txt
+	chineseSimplifiedWords string
+	//go:embed wordlists/chinese_traditional.txt
+	chineseTraditionalWords string
+	//go:embed wordlists/czech.txt
+	czechWords string
+	//go:embed wordlists/french.txt
+	frenchWords string
+	//go:embed wordlists/italian.txt
+	italianWords string
+	//go:embed wordlists/japanese.txt
+	japaneseWords string
+	//go:embed wordlists/korean.txt
+	koreanWords string
+	//go:embed wordlists/spanish.txt
+	spanishWords string
+)
+
+// wordCount is the number of words in every BIP-39 wordlist; each word
+// encodes 11 bits.
+const wordCount = 1 << bitsPerWord
+
+// Wordlist is one of the official BIP-39 wordlists of 2048 words.
+type Wordlist struct {
+	name      string
+	separator string
+	words     []string
+	index     map[string]int
+}
+
+var (
+	// English is the English wordlist, the default when a nil *Wordlist is passed.
+	English = newWordlist("english", " ", englishWords)
+	// ChineseSimplified is the Simplified Chinese wordlist.
+	ChineseSimplified = newWordlist("chinese_simplified", " ", chineseSimplifiedWords)
+	// ChineseTraditional is the Traditional Chinese wordlist.
+	ChineseTraditional = newWordlist("chinese_traditional", " ", chineseTraditionalWords)
+	// Czech is the Czech wordlist.
+	Czech = newWordlist("czech", " ", czechWords)
+	// French is the French wordlist.
+	French = newWordlist("french", " ", frenchWords)
+	// Italian is the Italian wordlist.
+	Italian = newWordlist("italian", " ", italianWords)
+	// Japanese is the Japanese wordlist. Mnemonics are joined with the
+	// ideographic space U+3000, as BIP-39 recommends for Japanese.
+	Japanese = newWordlist("japanese", "\u3000", japaneseWords)
+	// Korean is the Korean wordlist.
+	Korean = newWordlist("korean", " ", koreanWords)
+	// Spanish is the Spanish wordlist.
+	Spanish = newWordlist("spanish", " ", spanishWords)
+)
+
+// newWordlist parses an embedded wordlist with one word per line.
+func newWordlist(name, separator, data string) *Wordlist {
+	words := strings.Fields(data)
+	if len(words) != wordCount {
+		panic("bip39: wordlist " + name + " does not have 2048 words")
+	}
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		index[w] = i
+	}
+	return &Wordlist{name: name, separator: separator, words: words, index: index}
+}
+
+// orEnglish returns wl, or English when wl is nil.
+func orEnglish(wl *Wordlist) *Wordlist {
+	if wl == nil {
+		return English
+	}
+	return wl
+}
+
+// Name returns the file name of the wordlist in the BIP-39 repository
+// without extension, such as "english" or "chinese_simplified".
+func (wl *Wordlist) Name() string {
+	return wl.name
+}
+
+// Word returns the word with index i.
+// It panics if i is not in [0, 2048).
+func (wl *Wordlist) Word(i int) string {
+	return wl.words[i]
+}
+
+// Index returns the index of word in wl, or false if it is not a member.
+// Words are matched exactly, so accented words must be given in NFKD form.
+func (wl *Wordlist) Index(word string) (int, bool) {
+	i, ok := wl.index[word]
+	return i, ok
+}
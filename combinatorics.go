@@ -0,0 +1,121 @@
+package randutils
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// RandomPermutation returns a uniformly random permutation of the integers [0, n).
+// It returns an error if n <= 0.
+func RandomPermutation(n int) ([]int, error) {
+	return RandomPermutationWith(defaultGenerator, n)
+}
+
+// RandomPermutationWith is like RandomPermutation but draws from g.
+func RandomPermutationWith(g *Generator, n int) ([]int, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid size: %d", n)
+	}
+	perm := identity(n)
+	if err := ShuffleWith(g, perm); err != nil {
+		return nil, err
+	}
+	return perm, nil
+}
+
+// RandomCombination returns a uniformly random k-combination of [0, n)
+// as k distinct integers in ascending order.
+// It returns an error if n <= 0, k <= 0 or k > n.
+func RandomCombination(n, k int) ([]int, error) {
+	return RandomCombinationWith(defaultGenerator, n, k)
+}
+
+// RandomCombinationWith is like RandomCombination but draws from g.
+func RandomCombinationWith(g *Generator, n, k int) ([]int, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid size: %d", n)
+	}
+	idx, err := sampleIndices(g, n, k)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(idx)
+	return idx, nil
+}
+
+// RandomSubset returns a random subset of [0, n) in ascending order, where
+// each integer is included independently with probability p. The result may
+// be empty. It returns an error if n <= 0 or p is not in [0, 1].
+func RandomSubset(n int, p float64) ([]int, error) {
+	return RandomSubsetWith(defaultGenerator, n, p)
+}
+
+// RandomSubsetWith is like RandomSubset but draws from g.
+func RandomSubsetWith(g *Generator, n int, p float64) ([]int, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid size: %d", n)
+	}
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return nil, fmt.Errorf("invalid probability: %v", p)
+	}
+	subset := []int{}
+	for i := range n {
+		// Float64 is a multiple of 2^-53 in [0, 1), so the comparison
+		// includes i with probability exactly p rounded to 53 bits.
+		u, err := g.Float64()
+		if err != nil {
+			return nil, err
+		}
+		if u < p {
+			subset = append(subset, i)
+		}
+	}
+	return subset, nil
+}
+
+// RandomDerangement returns a uniformly random derangement of [0, n), a
+// permutation in which no integer stays at its own index.
+// Shuffles without a fixed point are accepted as they are generated; about
+// e ≈ 2.72 shuffles are needed on average, independently of n.
+// It returns an error if n <= 1, since no derangement of a single element exists.
+func RandomDerangement(n int) ([]int, error) {
+	return RandomDerangementWith(defaultGenerator, n)
+}
+
+// RandomDerangementWith is like RandomDerangement but draws from g.
+func RandomDerangementWith(g *Generator, n int) ([]int, error) {
+	if n <= 1 {
+		return nil, fmt.Errorf("invalid size: %d", n)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for {
+		perm := identity(n)
+		if err := shuffleLocked(g, perm); err != nil {
+			return nil, err
+		}
+		if !hasFixedPoint(perm) {
+			return perm, nil
+		}
+	}
+}
+
+// identity returns the slice [0, 1, ..., n-1].
+func identity(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// hasFixedPoint reports whether perm maps any index to itself.
+func hasFixedPoint(perm []int) bool {
+	for i, v := range perm {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
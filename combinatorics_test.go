@@ -0,0 +1,160 @@
+package randutils
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// chiSquareLimit returns the Wilson-Hilferty approximation of the 0.999
+// quantile of the chi-square distribution with k degrees of freedom.
+func chiSquareLimit(k int) float64 {
+	df := float64(k)
+	return df * math.Pow(1-2/(9*df)+3.09*math.Sqrt(2/(9*df)), 3)
+}
+
+// chiSquareUniform returns the chi-square statistic of counts against equal expected frequencies.
+func chiSquareUniform[K comparable](counts map[K]int, cells, samples int) float64 {
+	expected := float64(samples) / float64(cells)
+	chi2 := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi2 += d * d / expected
+	}
+	return chi2
+}
+
+// TestRandomPermutation_Uniform tests that all 24 permutations of 4 elements are equally likely
+func TestRandomPermutation_Uniform(t *testing.T) {
+	const samples = 48000
+	g := NewSeeded(testSeed())
+	counts := make(map[[4]int]int)
+	for range samples {
+		perm, err := RandomPermutationWith(g, 4)
+		if err != nil {
+			t.Fatalf("RandomPermutationWith() error = %v", err)
+		}
+		counts[[4]int(perm)]++
+	}
+	if len(counts) != 24 {
+		t.Fatalf("RandomPermutationWith() produced %d permutations, want 24", len(counts))
+	}
+	if chi2, limit := chiSquareUniform(counts, 24, samples), chiSquareLimit(23); chi2 > limit {
+		t.Errorf("chi-square = %.2f, want <= %.2f", chi2, limit)
+	}
+}
+
+// TestRandomCombination_Uniform tests that all 20 3-combinations of 6 elements are equally likely
+func TestRandomCombination_Uniform(t *testing.T) {
+	const samples = 40000
+	g := NewSeeded(testSeed())
+	counts := make(map[[3]int]int)
+	for range samples {
+		comb, err := RandomCombinationWith(g, 6, 3)
+		if err != nil {
+			t.Fatalf("RandomCombinationWith() error = %v", err)
+		}
+		if !slices.IsSorted(comb) {
+			t.Fatalf("RandomCombinationWith() = %v, want ascending order", comb)
+		}
+		counts[[3]int(comb)]++
+	}
+	if len(counts) != 20 {
+		t.Fatalf("RandomCombinationWith() produced %d combinations, want 20", len(counts))
+	}
+	if chi2, limit := chiSquareUniform(counts, 20, samples), chiSquareLimit(19); chi2 > limit {
+		t.Errorf("chi-square = %.2f, want <= %.2f", chi2, limit)
+	}
+}
+
+// TestRandomSubset tests the inclusion frequency and edge probabilities of RandomSubset
+func TestRandomSubset(t *testing.T) {
+	const (
+		n       = 10
+		samples = 20000
+		p       = 0.3
+	)
+	g := NewSeeded(testSeed())
+	counts := make(map[int]int)
+	for range samples {
+		subset, err := RandomSubsetWith(g, n, p)
+		if err != nil {
+			t.Fatalf("RandomSubsetWith() error = %v", err)
+		}
+		if !slices.IsSorted(subset) {
+			t.Fatalf("RandomSubsetWith() = %v, want ascending order", subset)
+		}
+		for _, v := range subset {
+			counts[v]++
+		}
+	}
+	// Each count is binomial(samples, p); allow five standard deviations.
+	mean := samples * p
+	tolerance := 5 * math.Sqrt(samples*p*(1-p))
+	for i := range n {
+		if math.Abs(float64(counts[i])-mean) > tolerance {
+			t.Errorf("index %d included %d times, want %.0f ± %.0f", i, counts[i], mean, tolerance)
+		}
+	}
+
+	none, err := RandomSubset(n, 0)
+	if err != nil || len(none) != 0 {
+		t.Errorf("RandomSubset(%d, 0) = %v, %v, want empty", n, none, err)
+	}
+	all, err := RandomSubset(n, 1)
+	if err != nil || !slices.Equal(all, identity(n)) {
+		t.Errorf("RandomSubset(%d, 1) = %v, %v, want every index", n, all, err)
+	}
+}
+
+// TestRandomDerangement_Uniform tests that all 9 derangements of 4 elements are equally likely
+func TestRandomDerangement_Uniform(t *testing.T) {
+	const samples = 27000
+	g := NewSeeded(testSeed())
+	counts := make(map[[4]int]int)
+	for range samples {
+		perm, err := RandomDerangementWith(g, 4)
+		if err != nil {
+			t.Fatalf("RandomDerangementWith() error = %v", err)
+		}
+		if hasFixedPoint(perm) {
+			t.Fatalf("RandomDerangementWith() = %v, has a fixed point", perm)
+		}
+		counts[[4]int(perm)]++
+	}
+	if len(counts) != 9 {
+		t.Fatalf("RandomDerangementWith() produced %d derangements, want 9", len(counts))
+	}
+	if chi2, limit := chiSquareUniform(counts, 9, samples), chiSquareLimit(8); chi2 > limit {
+		t.Errorf("chi-square = %.2f, want <= %.2f", chi2, limit)
+	}
+
+	pair, err := RandomDerangement(2)
+	if err != nil || !slices.Equal(pair, []int{1, 0}) {
+		t.Errorf("RandomDerangement(2) = %v, %v, want [1 0]", pair, err)
+	}
+}
+
+// TestCombinatorics_Errors tests the validation of the combinatorial generators
+func TestCombinatorics_Errors(t *testing.T) {
+	calls := map[string]func() error{
+		"permutation zero":      func() error { _, err := RandomPermutation(0); return err },
+		"combination zero n":    func() error { _, err := RandomCombination(0, 0); return err },
+		"combination zero k":    func() error { _, err := RandomCombination(5, 0); return err },
+		"combination k > n":     func() error { _, err := RandomCombination(5, 6); return err },
+		"subset negative n":     func() error { _, err := RandomSubset(-1, 0.5); return err },
+		"subset p > 1":          func() error { _, err := RandomSubset(5, 1.5); return err },
+		"subset negative p":     func() error { _, err := RandomSubset(5, -0.1); return err },
+		"subset NaN p":          func() error { _, err := RandomSubset(5, math.NaN()); return err },
+		"derangement of one":    func() error { _, err := RandomDerangement(1); return err },
+		"permutation src error": func() error { _, err := RandomPermutationWith(New(errSource{}), 3); return err },
+		"derangement src error": func() error { _, err := RandomDerangementWith(New(errSource{}), 3); return err },
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); err == nil {
+				t.Errorf("%s: error = nil, want error", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package distributions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/chaosoffire/go-randutils"
+)
+
+// Normal samples the normal distribution with the given mean and standard deviation.
+type Normal struct {
+	g      *randutils.Generator
+	mean   float64
+	stddev float64
+}
+
+// NewNormal returns a Normal sampler. It returns an error if mean is not
+// finite or stddev is not a finite positive number.
+func NewNormal(g *randutils.Generator, mean, stddev float64) (*Normal, error) {
+	if !finite(mean) {
+		return nil, fmt.Errorf("invalid mean: %v", mean)
+	}
+	if !finite(stddev) || stddev <= 0 {
+		return nil, fmt.Errorf("invalid stddev: %v", stddev)
+	}
+	return &Normal{g: generator(g), mean: mean, stddev: stddev}, nil
+}
+
+// Sample returns a normal variate.
+func (d *Normal) Sample() (float64, error) {
+	z, err := stdNormal(d.g)
+	if err != nil {
+		return 0, err
+	}
+	return d.mean + d.stddev*z, nil
+}
+
+// LogNormal samples a variate whose natural logarithm is normal with mean mu and standard deviation sigma.
+type LogNormal struct {
+	g     *randutils.Generator
+	mu    float64
+	sigma float64
+}
+
+// NewLogNormal returns a LogNormal sampler. It returns an error if mu is not
+// finite or sigma is not a finite positive number.
+func NewLogNormal(g *randutils.Generator, mu, sigma float64) (*LogNormal, error) {
+	if !finite(mu) {
+		return nil, fmt.Errorf("invalid mu: %v", mu)
+	}
+	if !finite(sigma) || sigma <= 0 {
+		return nil, fmt.Errorf("invalid sigma: %v", sigma)
+	}
+	return &LogNormal{g: generator(g), mu: mu, sigma: sigma}, nil
+}
+
+// Sample returns a log-normal variate.
+func (d *LogNormal) Sample() (float64, error) {
+	z, err := stdNormal(d.g)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(d.mu + d.sigma*z), nil
+}
+
+// Exponential samples the exponential distribution with the given rate (inverse mean).
+type Exponential struct {
+	g    *randutils.Generator
+	rate float64
+}
+
+// NewExponential returns an Exponential sampler. It returns an error if rate
+// is not a finite positive number.
+func NewExponential(g *randutils.Generator, rate float64) (*Exponential, error) {
+	if !finite(rate) || rate <= 0 {
+		return nil, fmt.Errorf("invalid rate: %v", rate)
+	}
+	return &Exponential{g: generator(g), rate: rate}, nil
+}
+
+// Sample returns an exponential variate.
+func (d *Exponential) Sample() (float64, error) {
+	x, err := stdExponential(d.g)
+	if err != nil {
+		return 0, err
+	}
+	return x / d.rate, nil
+}
+
+// Gamma samples the gamma distribution with the given shape (k) and scale (theta).
+type Gamma struct {
+	g     *randutils.Generator
+	shape float64
+	scale float64
+}
+
+// NewGamma returns a Gamma sampler. It returns an error if shape or scale is
+// not a finite positive number.
+func NewGamma(g *randutils.Generator, shape, scale float64) (*Gamma, error) {
+	if !finite(shape) || shape <= 0 {
+		return nil, fmt.Errorf("invalid shape: %v", shape)
+	}
+	if !finite(scale) || scale <= 0 {
+		return nil, fmt.Errorf("invalid scale: %v", scale)
+	}
+	return &Gamma{g: generator(g), shape: shape, scale: scale}, nil
+}
+
+// Sample returns a gamma variate.
+func (d *Gamma) Sample() (float64, error) {
+	x, err := stdGamma(d.g, d.shape)
+	if err != nil {
+		return 0, err
+	}
+	return x * d.scale, nil
+}
+
+// Beta samples the beta distribution on [0, 1] with shape parameters alpha and beta.
+type Beta struct {
+	g     *randutils.Generator
+	alpha float64
+	beta  float64
+}
+
+// NewBeta returns a Beta sampler. It returns an error if alpha or beta is not
+// a finite positive number.
+func NewBeta(g *randutils.Generator, alpha, beta float64) (*Beta, error) {
+	if !finite(alpha) || alpha <= 0 {
+		return nil, fmt.Errorf("invalid alpha: %v", alpha)
+	}
+	if !finite(beta) || beta <= 0 {
+		return nil, fmt.Errorf("invalid beta: %v", beta)
+	}
+	return &Beta{g: generator(g), alpha: alpha, beta: beta}, nil
+}
+
+// Sample returns a beta variate.
+func (d *Beta) Sample() (float64, error) {
+	return stdBeta(d.g, d.alpha, d.beta)
+}
+
+// Pareto samples the Pareto (type I) distribution with minimum xm and tail index alpha.
+type Pareto struct {
+	g     *randutils.Generator
+	xm    float64
+	alpha float64
+}
+
+// NewPareto returns a Pareto sampler. It returns an error if xm or alpha is
+// not a finite positive number.
+func NewPareto(g *randutils.Generator, xm, alpha float64) (*Pareto, error) {
+	if !finite(xm) || xm <= 0 {
+		return nil, fmt.Errorf("invalid xm: %v", xm)
+	}
+	if !finite(alpha) || alpha <= 0 {
+		return nil, fmt.Errorf("invalid alpha: %v", alpha)
+	}
+	return &Pareto{g: generator(g), xm: xm, alpha: alpha}, nil
+}
+
+// Sample returns a Pareto variate, always at least xm.
+func (d *Pareto) Sample() (float64, error) {
+	x, err := stdExponential(d.g)
+	if err != nil {
+		return 0, err
+	}
+	return d.xm * math.Exp(x/d.alpha), nil
+}
+
+// Weibull samples the Weibull distribution with the given scale (lambda) and shape (k).
+type Weibull struct {
+	g     *randutils.Generator
+	scale float64
+	shape float64
+}
+
+// NewWeibull returns a Weibull sampler. It returns an error if scale or shape
+// is not a finite positive number.
+func NewWeibull(g *randutils.Generator, scale, shape float64) (*Weibull, error) {
+	if !finite(scale) || scale <= 0 {
+		return nil, fmt.Errorf("invalid scale: %v", scale)
+	}
+	if !finite(shape) || shape <= 0 {
+		return nil, fmt.Errorf("invalid shape: %v", shape)
+	}
+	return &Weibull{g: generator(g), scale: scale, shape: shape}, nil
+}
+
+// Sample returns a Weibull variate.
+func (d *Weibull) Sample() (float64, error) {
+	x, err := stdExponential(d.g)
+	if err != nil {
+		return 0, err
+	}
+	return d.scale * math.Pow(x, 1/d.shape), nil
+}
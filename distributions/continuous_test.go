@@ -0,0 +1,126 @@
+package distributions
+
+import (
+	"math"
+	"testing"
+)
+
+// normalCDF is the CDF of the normal distribution
+func normalCDF(mean, stddev float64) func(float64) float64 {
+	return func(x float64) float64 {
+		return 0.5 * math.Erfc(-(x-mean)/(stddev*math.Sqrt2))
+	}
+}
+
+// TestContinuous_GoodnessOfFit runs KS tests against closed-form CDFs
+func TestContinuous_GoodnessOfFit(t *testing.T) {
+	g := seeded()
+	must := func(s interface{ Sample() (float64, error) }, err error) func() (float64, error) {
+		if err != nil {
+			t.Fatalf("constructor error = %v", err)
+		}
+		return s.Sample
+	}
+	tests := []struct {
+		name   string
+		sample func() (float64, error)
+		cdf    func(float64) float64
+	}{
+		{"Normal(3, 2)", must(NewNormal(g, 3, 2)), normalCDF(3, 2)},
+		{"LogNormal(0.5, 0.75)", must(NewLogNormal(g, 0.5, 0.75)), func(x float64) float64 {
+			return normalCDF(0.5, 0.75)(math.Log(x))
+		}},
+		{"Exponential(2.5)", must(NewExponential(g, 2.5)), func(x float64) float64 {
+			return 1 - math.Exp(-2.5*x)
+		}},
+		// Gamma(0.5, 1) is half a chi-square variate with one degree of freedom
+		{"Gamma(0.5, 1)", must(NewGamma(g, 0.5, 1)), func(x float64) float64 {
+			return math.Erf(math.Sqrt(x))
+		}},
+		// Gamma(3, 2) is an Erlang distribution with a closed-form CDF
+		{"Gamma(3, 2)", must(NewGamma(g, 3, 2)), func(x float64) float64 {
+			y := x / 2
+			return 1 - math.Exp(-y)*(1+y+y*y/2)
+		}},
+		{"Beta(2.5, 1)", must(NewBeta(g, 2.5, 1)), func(x float64) float64 {
+			return math.Pow(x, 2.5)
+		}},
+		{"Beta(0.5, 0.5)", must(NewBeta(g, 0.5, 0.5)), func(x float64) float64 {
+			return 2 / math.Pi * math.Asin(math.Sqrt(x))
+		}},
+		{"Pareto(1.5, 3)", must(NewPareto(g, 1.5, 3)), func(x float64) float64 {
+			return 1 - math.Pow(1.5/x, 3)
+		}},
+		{"Weibull(2, 1.5)", must(NewWeibull(g, 2, 1.5)), func(x float64) float64 {
+			return 1 - math.Exp(-math.Pow(x/2, 1.5))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ksTest(t, tt.name, tt.sample, tt.cdf)
+		})
+	}
+}
+
+// TestContinuous_Validation tests parameter validation of the continuous constructors
+func TestContinuous_Validation(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Normal zero stddev", second(NewNormal(nil, 0, 0))},
+		{"Normal negative stddev", second(NewNormal(nil, 0, -1))},
+		{"Normal NaN mean", second(NewNormal(nil, nan, 1))},
+		{"LogNormal infinite sigma", second(NewLogNormal(nil, 0, inf))},
+		{"Exponential zero rate", second(NewExponential(nil, 0))},
+		{"Gamma negative shape", second(NewGamma(nil, -1, 1))},
+		{"Gamma NaN scale", second(NewGamma(nil, 1, nan))},
+		{"Beta zero alpha", second(NewBeta(nil, 0, 1))},
+		{"Beta infinite beta", second(NewBeta(nil, 1, inf))},
+		{"Pareto zero xm", second(NewPareto(nil, 0, 1))},
+		{"Pareto negative alpha", second(NewPareto(nil, 1, -2))},
+		{"Weibull zero scale", second(NewWeibull(nil, 0, 1))},
+		{"Weibull NaN shape", second(NewWeibull(nil, 1, nan))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("constructor error = nil, want error")
+			}
+		})
+	}
+}
+
+// second returns the error of a constructor call
+func second[T any](_ T, err error) error {
+	return err
+}
+
+// TestBeta_TinyShapes tests that shapes small enough to underflow the gamma
+// variates still sample promptly and split their mass between 0 and 1
+func TestBeta_TinyShapes(t *testing.T) {
+	d, err := NewBeta(seeded(), 1e-15, 1e-15)
+	if err != nil {
+		t.Fatalf("NewBeta() error = %v", err)
+	}
+	const n = 2000
+	upper := 0
+	for range n {
+		x, err := d.Sample()
+		if err != nil {
+			t.Fatalf("Sample() error = %v", err)
+		}
+		if !(x >= 0 && x <= 1) {
+			t.Fatalf("Sample() = %v, want value in [0, 1]", x)
+		}
+		if x > 0.5 {
+			upper++
+		}
+	}
+	if frac := float64(upper) / n; frac < 0.45 || frac > 0.55 {
+		t.Errorf("fraction above 0.5 = %v, want about 0.5", frac)
+	}
+}
@@ -0,0 +1,236 @@
+package distributions
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/chaosoffire/go-randutils"
+)
+
+// smallMean is the expected count below which Poisson and Binomial use
+// direct methods whose cost grows with the mean.
+const smallMean = 30
+
+// maxLambda is the largest Poisson mean accepted, far enough below
+// math.MaxInt64 that samples always fit in an int64.
+const maxLambda = 1 << 62
+
+// Poisson samples the number of events in an interval with the given mean rate.
+type Poisson struct {
+	g      *randutils.Generator
+	lambda float64
+}
+
+// NewPoisson returns a Poisson sampler. It returns an error if lambda is not
+// a positive number of at most 2^62.
+func NewPoisson(g *randutils.Generator, lambda float64) (*Poisson, error) {
+	if !finite(lambda) || lambda <= 0 || lambda > maxLambda {
+		return nil, fmt.Errorf("invalid lambda: %v", lambda)
+	}
+	return &Poisson{g: generator(g), lambda: lambda}, nil
+}
+
+// Sample returns a Poisson variate.
+//
+// Large means are reduced exactly with Knuth's gamma decomposition (TAOCP
+// vol. 2, 3.4.1 F): with m = 7/8 of the mean, the m-th arrival time X of a
+// unit-rate process is gamma(m). If X < lambda the count is m plus a Poisson
+// count over the remaining lambda - X, otherwise it is binomial(m-1, lambda/X).
+func (d *Poisson) Sample() (int64, error) {
+	var n int64
+	lambda := d.lambda
+	for lambda >= smallMean {
+		m := math.Floor(lambda * 7 / 8)
+		x, err := stdGamma(d.g, m)
+		if err != nil {
+			return 0, err
+		}
+		if x >= lambda {
+			k, err := binomial(d.g, int64(m)-1, lambda/x)
+			return n + k, err
+		}
+		n += int64(m)
+		lambda -= x
+	}
+	// Count uniforms until their product falls below exp(-lambda).
+	limit := math.Exp(-lambda)
+	p := 1.0
+	for {
+		u, err := d.g.Float64Open()
+		if err != nil {
+			return 0, err
+		}
+		p *= u
+		if p <= limit {
+			return n, nil
+		}
+		n++
+	}
+}
+
+// Binomial samples the number of successes in n independent trials with success probability p.
+type Binomial struct {
+	g *randutils.Generator
+	n int64
+	p float64
+}
+
+// NewBinomial returns a Binomial sampler. It returns an error if n < 0 or p is outside [0, 1].
+func NewBinomial(g *randutils.Generator, n int64, p float64) (*Binomial, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid n: %d", n)
+	}
+	if !(p >= 0 && p <= 1) {
+		return nil, fmt.Errorf("invalid p: %v", p)
+	}
+	return &Binomial{g: generator(g), n: n, p: p}, nil
+}
+
+// Sample returns a binomial variate in [0, n].
+func (d *Binomial) Sample() (int64, error) {
+	return binomial(d.g, d.n, d.p)
+}
+
+// binomial samples Binomial(n, p).
+//
+// Large n is reduced exactly with Knuth's beta decomposition (TAOCP vol. 2,
+// 3.4.1 F): the a-th smallest of n uniforms, X, is beta(a, n+1-a). The
+// uniforms below p are then either binomial(a-1, p/X) among those below X,
+// or a plus binomial(n-a, (p-X)/(1-X)) among those above it. Small cases
+// sum geometric waiting times between successes.
+func binomial(g *randutils.Generator, n int64, p float64) (int64, error) {
+	if p > 0.5 {
+		k, err := binomial(g, n, 1-p)
+		return n - k, err
+	}
+	var k int64
+	for n > 0 && p > 0 && float64(n)*p >= smallMean {
+		a := 1 + n/2
+		x, err := stdBeta(g, float64(a), float64(n+1-a))
+		if err != nil {
+			return 0, err
+		}
+		if x >= p {
+			n, p = a-1, p/x
+		} else {
+			k += a
+			n, p = n-a, (p-x)/(1-x)
+		}
+	}
+	if n == 0 || p <= 0 {
+		return k, nil
+	}
+	if p >= 1 {
+		return k + n, nil
+	}
+	// Positions of successes advance by geometric waiting times.
+	logq := math.Log1p(-p)
+	var pos int64
+	for {
+		u, err := g.Float64Open()
+		if err != nil {
+			return 0, err
+		}
+		gap := math.Floor(math.Log(u) / logq)
+		if gap >= float64(n-pos) {
+			return k, nil
+		}
+		pos += int64(gap) + 1
+		k++
+	}
+}
+
+// Geometric samples the number of failures before the first success in
+// independent trials with success probability p.
+type Geometric struct {
+	g    *randutils.Generator
+	p    float64
+	logq float64
+}
+
+// NewGeometric returns a Geometric sampler. It returns an error if p is outside (0, 1].
+func NewGeometric(g *randutils.Generator, p float64) (*Geometric, error) {
+	if !(p > 0 && p <= 1) {
+		return nil, fmt.Errorf("invalid p: %v", p)
+	}
+	return &Geometric{g: generator(g), p: p, logq: math.Log1p(-p)}, nil
+}
+
+// Sample returns a geometric variate. Values beyond math.MaxInt64, which only
+// occur for vanishingly small p, are clamped.
+func (d *Geometric) Sample() (int64, error) {
+	if d.p == 1 {
+		return 0, nil
+	}
+	u, err := d.g.Float64Open()
+	if err != nil {
+		return 0, err
+	}
+	k := math.Floor(math.Log(u) / d.logq)
+	if k >= math.MaxInt64 {
+		return math.MaxInt64, nil
+	}
+	return int64(k), nil
+}
+
+// Zipf samples values k in [0, imax] with probability proportional to (v + k)^(-s).
+type Zipf struct {
+	g *randutils.Generator
+	s float64
+	v float64
+	// hLo and hSpan map a uniform variate u to y = hLo + u*hSpan, spanning
+	// the hat integral from H(imax + 1/2) down to H(1/2) - v^(-s).
+	hLo   float64
+	hSpan float64
+	// squeeze accepts k without evaluating H when x lies within it below k.
+	squeeze float64
+}
+
+// NewZipf returns a Zipf sampler. It returns an error if s <= 1, v < 1 or imax < 0.
+func NewZipf(g *randutils.Generator, s, v float64, imax int64) (*Zipf, error) {
+	if !finite(s) || s <= 1 {
+		return nil, fmt.Errorf("invalid s: %v", s)
+	}
+	if !finite(v) || v < 1 {
+		return nil, fmt.Errorf("invalid v: %v", v)
+	}
+	if imax < 0 {
+		return nil, fmt.Errorf("invalid imax: %d", imax)
+	}
+	z := &Zipf{g: generator(g), s: s, v: v}
+	z.hLo = z.hatIntegral(float64(imax) + 0.5)
+	z.hSpan = z.hatIntegral(0.5) - math.Pow(v, -s) - z.hLo
+	z.squeeze = 1 - z.hatIntegralInv(z.hatIntegral(1.5)-math.Pow(v+1, -s))
+	return z, nil
+}
+
+// hatIntegral returns H(x) = (v + x)^(1-s) / (1-s), the integral of the hat
+// function (v + x)^(-s) that bounds the unnormalized probabilities.
+func (z *Zipf) hatIntegral(x float64) float64 {
+	return math.Pow(z.v+x, 1-z.s) / (1 - z.s)
+}
+
+// hatIntegralInv is the inverse of hatIntegral.
+func (z *Zipf) hatIntegralInv(y float64) float64 {
+	return math.Pow((1-z.s)*y, 1/(1-z.s)) - z.v
+}
+
+// Sample returns a Zipf variate using the rejection-inversion method of
+// W. Hörmann and G. Derflinger, "Rejection-inversion to generate variates
+// from monotone discrete distributions", ACM TOMACS 6(3), 1996: a uniform
+// point under the hat is inverted and rounded to k, and accepted if it also
+// lies under the probability of k.
+func (z *Zipf) Sample() (int64, error) {
+	for {
+		u, err := z.g.Float64Closed()
+		if err != nil {
+			return 0, err
+		}
+		y := z.hLo + u*z.hSpan
+		x := z.hatIntegralInv(y)
+		k := math.Floor(x + 0.5)
+		if k-x <= z.squeeze || y >= z.hatIntegral(k+0.5)-math.Pow(k+z.v, -z.s) {
+			return int64(k), nil
+		}
+	}
+}
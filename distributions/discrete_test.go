@@ -0,0 +1,140 @@
+package distributions
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDiscrete_GoodnessOfFit runs chi-square tests against exact probability mass functions
+func TestDiscrete_GoodnessOfFit(t *testing.T) {
+	g := seeded()
+	must := func(s interface{ Sample() (int64, error) }, err error) func() (int64, error) {
+		if err != nil {
+			t.Fatalf("constructor error = %v", err)
+		}
+		return s.Sample
+	}
+	poisson := func(lambda float64) func(int64) float64 {
+		return func(k int64) float64 {
+			lg, _ := math.Lgamma(float64(k + 1))
+			return math.Exp(float64(k)*math.Log(lambda) - lambda - lg)
+		}
+	}
+	binomial := func(n int64, p float64) func(int64) float64 {
+		return func(k int64) float64 {
+			return math.Exp(lchoose(n, k) + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+		}
+	}
+	zipf := func(s, v float64, imax int64) func(int64) float64 {
+		total := 0.0
+		for k := int64(0); k <= imax; k++ {
+			total += math.Pow(v+float64(k), -s)
+		}
+		return func(k int64) float64 {
+			return math.Pow(v+float64(k), -s) / total
+		}
+	}
+	tests := []struct {
+		name   string
+		sample func() (int64, error)
+		pmf    func(int64) float64
+		lo, hi int64
+	}{
+		{"Poisson(4)", must(NewPoisson(g, 4)), poisson(4), 0, 40},
+		{"Poisson(250)", must(NewPoisson(g, 250)), poisson(250), 150, 350},
+		{"Binomial(20, 0.3)", must(NewBinomial(g, 20, 0.3)), binomial(20, 0.3), 0, 20},
+		{"Binomial(1000, 0.4)", must(NewBinomial(g, 1000, 0.4)), binomial(1000, 0.4), 300, 500},
+		{"Binomial(500, 0.85)", must(NewBinomial(g, 500, 0.85)), binomial(500, 0.85), 380, 480},
+		{"Geometric(0.2)", must(NewGeometric(g, 0.2)), func(k int64) float64 {
+			return 0.2 * math.Pow(0.8, float64(k))
+		}, 0, 60},
+		{"Zipf(2, 1, 100)", must(NewZipf(g, 2, 1, 100)), zipf(2, 1, 100), 0, 100},
+		{"Zipf(1.5, 3, 1000)", must(NewZipf(g, 1.5, 3, 1000)), zipf(1.5, 3, 1000), 0, 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chiSquareTest(t, tt.name, tt.sample, tt.pmf, tt.lo, tt.hi)
+		})
+	}
+}
+
+// TestDiscrete_Degenerate tests parameters whose outcome is certain
+func TestDiscrete_Degenerate(t *testing.T) {
+	tests := []struct {
+		name string
+		want int64
+		new  func() (interface{ Sample() (int64, error) }, error)
+	}{
+		{"Binomial n=0", 0, func() (interface{ Sample() (int64, error) }, error) { return NewBinomial(nil, 0, 0.5) }},
+		{"Binomial p=0", 0, func() (interface{ Sample() (int64, error) }, error) { return NewBinomial(nil, 100, 0) }},
+		{"Binomial p=1", 100, func() (interface{ Sample() (int64, error) }, error) { return NewBinomial(nil, 100, 1) }},
+		{"Geometric p=1", 0, func() (interface{ Sample() (int64, error) }, error) { return NewGeometric(nil, 1) }},
+		{"Zipf imax=0", 0, func() (interface{ Sample() (int64, error) }, error) { return NewZipf(nil, 2, 1, 0) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := tt.new()
+			if err != nil {
+				t.Fatalf("constructor error = %v", err)
+			}
+			for range 20 {
+				k, err := d.Sample()
+				if err != nil {
+					t.Fatalf("Sample() error = %v", err)
+				}
+				if k != tt.want {
+					t.Errorf("Sample() = %d, want %d", k, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestPoisson_MaxLambda tests that the largest accepted mean yields samples near it
+func TestPoisson_MaxLambda(t *testing.T) {
+	d, err := NewPoisson(seeded(), maxLambda)
+	if err != nil {
+		t.Fatalf("NewPoisson() error = %v", err)
+	}
+	for range 20 {
+		k, err := d.Sample()
+		if err != nil {
+			t.Fatalf("Sample() error = %v", err)
+		}
+		if dev := math.Abs(float64(k) - maxLambda); dev > 10*math.Sqrt(maxLambda) {
+			t.Errorf("Sample() = %d, %.3g from the mean", k, dev)
+		}
+	}
+}
+
+// TestDiscrete_Validation tests parameter validation of the discrete constructors
+func TestDiscrete_Validation(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"Poisson zero lambda", second(NewPoisson(nil, 0))},
+		{"Poisson NaN lambda", second(NewPoisson(nil, nan))},
+		{"Poisson lambda above 2^62", second(NewPoisson(nil, 1e19))},
+		{"Poisson huge lambda", second(NewPoisson(nil, 1e300))},
+		{"Binomial negative n", second(NewBinomial(nil, -1, 0.5))},
+		{"Binomial p above one", second(NewBinomial(nil, 10, 1.5))},
+		{"Binomial NaN p", second(NewBinomial(nil, 10, nan))},
+		{"Geometric zero p", second(NewGeometric(nil, 0))},
+		{"Geometric p above one", second(NewGeometric(nil, 2))},
+		{"Zipf s of one", second(NewZipf(nil, 1, 1, 10))},
+		{"Zipf v below one", second(NewZipf(nil, 2, 0.5, 10))},
+		{"Zipf negative imax", second(NewZipf(nil, 2, 1, -1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err == nil {
+				t.Errorf("constructor error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package distributions
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/chaosoffire/go-randutils"
+)
+
+// sampleCount is the number of draws used by the goodness-of-fit tests
+const sampleCount = 20000
+
+// seeded returns a deterministic generator so the goodness-of-fit tests are reproducible
+func seeded() *randutils.Generator {
+	var seed randutils.Seed
+	for i := range seed {
+		seed[i] = byte(i * 7)
+	}
+	return randutils.NewSeeded(seed)
+}
+
+// ksTest runs a one-sample Kolmogorov-Smirnov test of sample against cdf at the 0.1% level
+func ksTest(t *testing.T, name string, sample func() (float64, error), cdf func(float64) float64) {
+	t.Helper()
+	xs := make([]float64, sampleCount)
+	for i := range xs {
+		x, err := sample()
+		if err != nil {
+			t.Fatalf("%s Sample() error = %v", name, err)
+		}
+		xs[i] = x
+	}
+	slices.Sort(xs)
+	d := 0.0
+	for i, x := range xs {
+		f := cdf(x)
+		d = max(d, f-float64(i)/sampleCount, float64(i+1)/sampleCount-f)
+	}
+	// Asymptotic critical value of the KS statistic at alpha = 0.001
+	if limit := 1.949 / math.Sqrt(sampleCount); d > limit {
+		t.Errorf("%s: KS statistic %.4f exceeds %.4f", name, d, limit)
+	}
+}
+
+// chiSquareTest runs a chi-square test of discrete samples against pmf at the 0.1% level.
+// Outcomes whose expected count is below 5 are pooled into a single tail bin.
+func chiSquareTest(t *testing.T, name string, sample func() (int64, error), pmf func(int64) float64, lo, hi int64) {
+	t.Helper()
+	counts := make(map[int64]int)
+	for range sampleCount {
+		k, err := sample()
+		if err != nil {
+			t.Fatalf("%s Sample() error = %v", name, err)
+		}
+		counts[k]++
+	}
+	chi2, bins := 0.0, 0
+	restObserved, restExpected := sampleCount, float64(sampleCount)
+	for k := lo; k <= hi; k++ {
+		expected := pmf(k) * sampleCount
+		if expected < 5 {
+			continue
+		}
+		observed := counts[k]
+		restObserved -= observed
+		restExpected -= expected
+		diff := float64(observed) - expected
+		chi2 += diff * diff / expected
+		bins++
+	}
+	if restExpected >= 5 {
+		diff := float64(restObserved) - restExpected
+		chi2 += diff * diff / restExpected
+		bins++
+	} else if restObserved > 20 {
+		t.Errorf("%s: %d samples fell in bins with expected count %.2f", name, restObserved, restExpected)
+	}
+	// Wilson-Hilferty approximation of the 0.999 quantile
+	df := float64(bins - 1)
+	limit := df * math.Pow(1-2/(9*df)+3.09*math.Sqrt(2/(9*df)), 3)
+	if chi2 > limit {
+		t.Errorf("%s: chi-square %.2f exceeds %.2f with %d bins", name, chi2, limit, bins)
+	}
+}
+
+// lchoose returns log(n choose k)
+func lchoose(n, k int64) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// TestNilGenerator tests that a nil generator selects the crypto default
+func TestNilGenerator(t *testing.T) {
+	d, err := NewNormal(nil, 0, 1)
+	if err != nil {
+		t.Fatalf("NewNormal() error = %v", err)
+	}
+	if d.g != randutils.Default() {
+		t.Errorf("NewNormal(nil, ...) did not select randutils.Default()")
+	}
+	if _, err := d.Sample(); err != nil {
+		t.Errorf("Sample() error = %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package drbg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	ctrKeySize = 32
+	// CTRSeedSize is the seed length of CTR_DRBG with AES-256 in bytes. Entropy
+	// input is read in blocks of this size, and personalization strings and
+	// additional input may be at most this long.
+	CTRSeedSize = ctrKeySize + aes.BlockSize
+)
+
+// CTR is an SP 800-90A CTR_DRBG using AES-256 without a derivation function.
+// Because no derivation function is used, the entropy source must deliver
+// full-entropy bytes, as crypto/rand does. It is safe for concurrent use.
+type CTR struct {
+	mu       sync.Mutex
+	entropy  io.Reader
+	interval uint64
+	pr       bool
+
+	block   cipher.Block
+	v       [aes.BlockSize]byte
+	counter uint64
+}
+
+// NewCTR instantiates a CTR_DRBG, reading CTRSeedSize bytes of entropy input from opts.Entropy.
+func NewCTR(opts Options) (*CTR, error) {
+	interval, err := opts.reseedInterval()
+	if err != nil {
+		return nil, err
+	}
+	pers, err := padSeed(opts.Personalization)
+	if err != nil {
+		return nil, err
+	}
+	d := &CTR{
+		entropy:  opts.entropySource(),
+		interval: interval,
+		pr:       opts.PredictionResistance,
+	}
+	entropy, err := readEntropy(d.entropy, CTRSeedSize)
+	if err != nil {
+		return nil, err
+	}
+	d.block, err = aes.NewCipher(make([]byte, ctrKeySize))
+	if err != nil {
+		return nil, err
+	}
+	xorBytes(entropy, pers[:])
+	d.update((*[CTRSeedSize]byte)(entropy))
+	d.counter = 1
+	return d, nil
+}
+
+// padSeed right-pads input with zeros to CTRSeedSize bytes.
+func padSeed(input []byte) (*[CTRSeedSize]byte, error) {
+	if len(input) > CTRSeedSize {
+		return nil, fmt.Errorf("invalid input length: %d > %d", len(input), CTRSeedSize)
+	}
+	var seed [CTRSeedSize]byte
+	copy(seed[:], input)
+	return &seed, nil
+}
+
+// Reseed mixes fresh entropy and optional additional input into the state.
+func (d *CTR) Reseed(additional []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.reseed(additional)
+}
+
+// Generate fills out with pseudorandom bytes, mixing in optional additional input.
+// It reseeds first when prediction resistance is enabled or the reseed interval has elapsed.
+func (d *CTR) Generate(out, additional []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.generate(out, additional)
+}
+
+// Read fills p with pseudorandom bytes, issuing one generate request per MaxRequestSize bytes.
+func (d *CTR) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return readChunks(p, func(out []byte) error {
+		return d.generate(out, nil)
+	})
+}
+
+func (d *CTR) reseed(additional []byte) error {
+	add, err := padSeed(additional)
+	if err != nil {
+		return err
+	}
+	entropy, err := readEntropy(d.entropy, CTRSeedSize)
+	if err != nil {
+		return err
+	}
+	xorBytes(entropy, add[:])
+	d.update((*[CTRSeedSize]byte)(entropy))
+	d.counter = 1
+	return nil
+}
+
+func (d *CTR) generate(out, additional []byte) error {
+	if len(out) > MaxRequestSize {
+		return fmt.Errorf("invalid request size: %d", len(out))
+	}
+	if d.pr || d.counter > d.interval {
+		if err := d.reseed(additional); err != nil {
+			return err
+		}
+		additional = nil
+	}
+	add, err := padSeed(additional)
+	if err != nil {
+		return err
+	}
+	if len(additional) > 0 {
+		d.update(add)
+	}
+	var block [aes.BlockSize]byte
+	for n := 0; n < len(out); {
+		increment(&d.v)
+		d.block.Encrypt(block[:], d.v[:])
+		n += copy(out[n:], block[:])
+	}
+	d.update(add)
+	d.counter++
+	return nil
+}
+
+// update is CTR_DRBG_Update (SP 800-90A section 10.2.1.2).
+func (d *CTR) update(provided *[CTRSeedSize]byte) {
+	var temp [CTRSeedSize]byte
+	for n := 0; n < len(temp); n += aes.BlockSize {
+		increment(&d.v)
+		d.block.Encrypt(temp[n:], d.v[:])
+	}
+	xorBytes(temp[:], provided[:])
+	block, err := aes.NewCipher(temp[:ctrKeySize])
+	if err != nil {
+		// Unreachable: the key length is always valid for AES-256.
+		panic(err)
+	}
+	d.block = block
+	copy(d.v[:], temp[ctrKeySize:])
+}
+
+// increment adds one to v as a 128-bit big-endian counter.
+func increment(v *[aes.BlockSize]byte) {
+	for i := len(v) - 1; i >= 0; i-- {
+		v[i]++
+		if v[i] != 0 {
+			return
+		}
+	}
+}
+
+// xorBytes sets dst[i] ^= src[i] for every index of src.
+func xorBytes(dst, src []byte) {
+	for i := range src {
+		dst[i] ^= src[i]
+	}
+}
@@ -0,0 +1,90 @@
+// Package drbg implements the NIST SP 800-90A deterministic random bit
+// generators HMAC_DRBG and CTR_DRBG (AES-256, without derivation function).
+//
+// Both generators seed themselves from crypto/rand by default, support
+// personalization strings, additional input, configurable reseed intervals
+// and prediction resistance, and implement io.Reader so they can back a
+// randutils.Generator:
+//
+//	d, err := drbg.NewCTR(drbg.Options{Personalization: []byte("token-service")})
+//	if err != nil {
+//		return err
+//	}
+//	g := randutils.New(d)
+//
+// randutils.New reads its source 512 bytes at a time and serves many small
+// values from each read, so with PredictionResistance a single reseed covers
+// all of them. Use randutils.NewUnbuffered, or Generator.Byte and
+// Generator.Read, to make every value its own generate request.
+package drbg
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+)
+
+const (
+	// MaxReseedInterval is the largest number of generate requests allowed
+	// between reseeds by SP 800-90A for both HMAC_DRBG and CTR_DRBG.
+	MaxReseedInterval = 1 << 48
+	// MaxRequestSize is the largest number of bytes a single generate request
+	// may return (2^19 bits). Read splits larger reads into several requests.
+	MaxRequestSize = (1 << 19) / 8
+)
+
+// Options configures a DRBG instance.
+type Options struct {
+	// Entropy supplies entropy input and nonces. Nil selects crypto/rand.Reader.
+	Entropy io.Reader
+	// Personalization is mixed into the initial state to separate instances.
+	Personalization []byte
+	// ReseedInterval is the number of generate requests allowed before the
+	// generator reseeds itself from Entropy. Zero selects MaxReseedInterval.
+	ReseedInterval uint64
+	// PredictionResistance reseeds from Entropy before every generate request.
+	// Each Read is one request (or several above MaxRequestSize), so wrap the
+	// DRBG with randutils.NewUnbuffered to reseed before every random value.
+	PredictionResistance bool
+}
+
+// entropySource returns the configured entropy reader or crypto/rand.Reader.
+func (o Options) entropySource() io.Reader {
+	if o.Entropy == nil {
+		return crand.Reader
+	}
+	return o.Entropy
+}
+
+// reseedInterval validates and returns the effective reseed interval.
+func (o Options) reseedInterval() (uint64, error) {
+	if o.ReseedInterval > MaxReseedInterval {
+		return 0, fmt.Errorf("invalid reseed interval: %d", o.ReseedInterval)
+	}
+	if o.ReseedInterval == 0 {
+		return MaxReseedInterval, nil
+	}
+	return o.ReseedInterval, nil
+}
+
+// readEntropy reads n bytes of entropy input from r.
+func readEntropy(r io.Reader, n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, fmt.Errorf("failed to read entropy: %w", err)
+	}
+	return b, nil
+}
+
+// readChunks fills p with successive generate requests of at most MaxRequestSize bytes.
+func readChunks(p []byte, generate func(out []byte) error) (int, error) {
+	n := 0
+	for n < len(p) {
+		end := min(n+MaxRequestSize, len(p))
+		if err := generate(p[n:end]); err != nil {
+			return n, err
+		}
+		n = end
+	}
+	return n, nil
+}
@@ -0,0 +1,152 @@
+package drbg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+)
+
+// HMAC is an SP 800-90A HMAC_DRBG. It is safe for concurrent use.
+type HMAC struct {
+	mu       sync.Mutex
+	newHash  func() hash.Hash
+	entropy  io.Reader
+	strength int // security strength in bytes
+	interval uint64
+	pr       bool
+
+	k, v    []byte
+	counter uint64
+}
+
+// NewHMAC instantiates an HMAC_DRBG over newHash, which defaults to SHA-256 when nil.
+// Entropy input and nonce are read from opts.Entropy according to the
+// security strength of the hash (256 bits for SHA-256 and larger).
+func NewHMAC(newHash func() hash.Hash, opts Options) (*HMAC, error) {
+	if newHash == nil {
+		newHash = sha256.New
+	}
+	interval, err := opts.reseedInterval()
+	if err != nil {
+		return nil, err
+	}
+	d := &HMAC{
+		newHash:  newHash,
+		entropy:  opts.entropySource(),
+		strength: hashStrength(newHash().Size()),
+		interval: interval,
+		pr:       opts.PredictionResistance,
+	}
+	entropy, err := readEntropy(d.entropy, d.strength+d.strength/2)
+	if err != nil {
+		return nil, err
+	}
+	d.k = make([]byte, newHash().Size())
+	d.v = make([]byte, newHash().Size())
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.update(entropy, opts.Personalization)
+	d.counter = 1
+	return d, nil
+}
+
+// hashStrength returns the SP 800-57 security strength in bytes for a digest size.
+func hashStrength(size int) int {
+	switch {
+	case size <= 20:
+		return 16
+	case size <= 28:
+		return 24
+	default:
+		return 32
+	}
+}
+
+// Reseed mixes fresh entropy and optional additional input into the state.
+func (d *HMAC) Reseed(additional []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.reseed(additional)
+}
+
+// Generate fills out with pseudorandom bytes, mixing in optional additional input.
+// It reseeds first when prediction resistance is enabled or the reseed interval has elapsed.
+func (d *HMAC) Generate(out, additional []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.generate(out, additional)
+}
+
+// Read fills p with pseudorandom bytes, issuing one generate request per MaxRequestSize bytes.
+func (d *HMAC) Read(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return readChunks(p, func(out []byte) error {
+		return d.generate(out, nil)
+	})
+}
+
+func (d *HMAC) reseed(additional []byte) error {
+	entropy, err := readEntropy(d.entropy, d.strength)
+	if err != nil {
+		return err
+	}
+	d.update(entropy, additional)
+	d.counter = 1
+	return nil
+}
+
+func (d *HMAC) generate(out, additional []byte) error {
+	if len(out) > MaxRequestSize {
+		return fmt.Errorf("invalid request size: %d", len(out))
+	}
+	if d.pr || d.counter > d.interval {
+		if err := d.reseed(additional); err != nil {
+			return err
+		}
+		additional = nil
+	}
+	if len(additional) > 0 {
+		d.update(additional)
+	}
+	mac := hmac.New(d.newHash, d.k)
+	for n := 0; n < len(out); {
+		mac.Reset()
+		mac.Write(d.v)
+		d.v = mac.Sum(d.v[:0])
+		n += copy(out[n:], d.v)
+	}
+	d.update(additional)
+	d.counter++
+	return nil
+}
+
+// update is HMAC_DRBG_Update (SP 800-90A section 10.1.2.2) over the
+// concatenation of provided.
+func (d *HMAC) update(provided ...[]byte) {
+	empty := true
+	for _, p := range provided {
+		if len(p) > 0 {
+			empty = false
+		}
+	}
+	for _, sep := range []byte{0x00, 0x01} {
+		if sep == 0x01 && empty {
+			return
+		}
+		mac := hmac.New(d.newHash, d.k)
+		mac.Write(d.v)
+		mac.Write([]byte{sep})
+		for _, p := range provided {
+			mac.Write(p)
+		}
+		d.k = mac.Sum(d.k[:0])
+		mac = hmac.New(d.newHash, d.k)
+		mac.Write(d.v)
+		d.v = mac.Sum(d.v[:0])
+	}
+}
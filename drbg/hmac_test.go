@@ -0,0 +1,294 @@
+package drbg
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// decodeHex decodes a hex test vector or fails the test
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// countingReader is an entropy source of constant bytes that records how much was read
+type countingReader struct {
+	reads int
+	bytes int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.reads++
+	c.bytes += len(p)
+	for i := range p {
+		p[i] = 0x5a
+	}
+	return len(p), nil
+}
+
+// failingReader is an entropy source that always fails
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy failure")
+}
+
+// TestHMAC_CAVP tests HMAC_DRBG against the NIST CAVP HMAC_DRBG.rsp vectors
+// ([SHA-256], PredictionResistance = False, no personalization or additional input)
+func TestHMAC_CAVP(t *testing.T) {
+	tests := []struct {
+		name         string
+		entropyInput string
+		nonce        string
+		returnedBits string
+	}{
+		{
+			"SHA-256 COUNT 0",
+			"ca851911349384bffe89de1cbdc46e6831e44d34a4fb935ee285dd14b71a7488",
+			"659ba96c601dc69fc902940805ec0ca8",
+			"e528e9abf2dece54d47c7e75e5fe302149f817ea9fb4bee6f4199697d04d5b89" +
+				"d54fbb978a15b5c443c9ec21036d2460b6f73ebad0dc2aba6e624abf07745bc1" +
+				"07694bb7547bb0995f70de25d6b29e2d3011bb19d27676c07162c8b5ccde0668" +
+				"961df86803482cb37ed6d5c0bb8d50cf1f50d476aa0458bdaba806f48be9dcb8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seed := append(decodeHex(t, tt.entropyInput), decodeHex(t, tt.nonce)...)
+			d, err := NewHMAC(nil, Options{Entropy: bytes.NewReader(seed)})
+			if err != nil {
+				t.Fatalf("NewHMAC() error = %v", err)
+			}
+			want := decodeHex(t, tt.returnedBits)
+			got := make([]byte, len(want))
+			// CAVP generates twice and reports the second output
+			for range 2 {
+				if err := d.Generate(got, nil); err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Generate() = %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+// TestHMAC_CAVPPredictionResistance tests HMAC_DRBG against the NIST CAVP
+// HMAC_DRBG_pr.rsp vectors ([SHA-512], PredictionResistance = True). Each
+// vector is also replayed with explicit reseeds, which must match.
+func TestHMAC_CAVPPredictionResistance(t *testing.T) {
+	tests := []struct {
+		name            string
+		entropyInput    string
+		nonce           string
+		personalization string
+		additional      [2]string
+		entropyPR       [2]string
+		returnedBits    string
+	}{
+		{
+			"no inputs",
+			"64a8afb71975256b6196f3f93038ba8b7a4d7089f7f268134cb3f5926868e4d1",
+			"04c60b44fbf3bc198f4bc58bf1260d12",
+			"",
+			[2]string{},
+			[2]string{
+				"3a5aaf8749136a86c4e5aba81692d587133d29d3b7a63fa6204ed84e93be6aeb",
+				"f50472d313ef5797d1a290a7cae086052b57e8d5a20ed22ec7702dd424d935ea",
+			},
+			"4f61f6b5d46ea351dc6f8ff55bcb915d998c8e871b5e122dd95196da241c49a1" +
+				"170b1fc16ffa31a6dc4f0c4068ecc6e5cc0fa6966aedf72bcb19e666b191979f" +
+				"22580b6505c09a784e76f58d30af3abcbe840497ad88621a893ffe13af6aef0f" +
+				"8276f9540068943bb6bc51498a465129880df4c517f7fe70ec239c055102a78b" +
+				"8b0f26d36bc2634a0e61a1431850980c258326197cc80d07c3cafc49a20316a0" +
+				"fa2703f850b66ce274e839d6dddba4d3e744306d768b7437ec9c54ed864c7bca" +
+				"4ea8d0987d815e64f685e0726eb4223aa5eac1a0979fb335248ee59819c36c7c" +
+				"94dadf14474c7e2f10678da59f255474ea50c3ed5ccf86a399ba7f54ae96bff0",
+		},
+		{
+			"additional input",
+			"73afadfdf46ac9c528059ec5e4f940f120c19beda8d5b12ae692c1d3b1252675",
+			"4ce532c291c8ce823aeaf923b3be8c43",
+			"",
+			[2]string{
+				"7172619bf78c088c4f0d5b358f63cbcc019620c6ea9ffa31e040ec0d51665989",
+				"a0670a6df2033cb19b082a3c83fd2eecddd9b9caebf3aed0b781ae9d4ac8bbe2",
+			},
+			[2]string{
+				"8d8b2a82162bce020237440d3445d4ef91793b983202b0f8532be2d78c34469d",
+				"2c67fea05495feec67b76615967efa6f6bcde5bcf18285dd3d8f9b97b3463813",
+			},
+			"38ebc242f240569f792379afe393a76698fd07dc05d5c86d00791c1b9d1d79f1" +
+				"80c4360fc8f2e5332a961198d7486750671e14d39a2b4852aede2ae9745484ca" +
+				"05d7421191571d334cd714b9433ba026a058cab5619208f2e54f2d48286e49bd" +
+				"0b528d05785beb4ff8953fe875cd2c92277494f2e315ab2790a1cd58f0222438" +
+				"7470bd7edb3181d2b587e5c319a262c7806f8b75e59f2857871d8a182ba0366c" +
+				"d3a968023c22582ec7bad2a204de0eba3d24566f213c1d88ca2b2ca8cafd8149" +
+				"193949da885bd744323f31b39956fdea7bccb1d64d3f14afd03e1755962d9df1" +
+				"f2507098455584358e951f7ff8619f1aab96e1481ede5289224053f603a98ae6",
+		},
+		{
+			"personalization",
+			"d7d2a9a0b97f4564e05de6db7bf170d2a726e0f5eb2970839c4a0c686ef372fa",
+			"aa5d8afc07d7e9a44904fe9f7359d8b6",
+			"db994880895242ced06eb29157756b25052257bd49ca08c7208d51e7b0ddeeb7",
+			[2]string{},
+			[2]string{
+				"205c7ce06021f5dd60656247503694960c78aa5e3b3f5008d48c6a264bb94e1c",
+				"2950f734611e3e10291cdc0199ab9000a9c2eb74081b3c2cb4461ad6406a38e7",
+			},
+			"6a45639360130d0a679f9addcbf6f46b9945b3b1e5a72eb175144e62786dbcbc" +
+				"8073cc2be8cac421b9576ec496452ecc1a611b1e5ac41500c4213404a2311247" +
+				"c5e828738a8cb55f67b97f39d05e36eb29871e3d709f3bc7c72567e776ae736b" +
+				"63c06f5b57c1127e305387b115f117e302727d042c2c0979b70e2a0674ace292" +
+				"2bcc2839c1a75044f740790b62b078bc3cb056a34a9ad7271e02a1fa86ec8522" +
+				"6ecbb9b126c4a9b3b0b0f4ac6915c641af28b34d7b7da6bbf4ce280671c52eb9" +
+				"19100e198a3feed6b4fd48c01d836c363904d640e475e0d0e6c6ce5f25d0b174" +
+				"c561ecbbae201bac53d8499706d83da43c268bc2c57e2405ed016d6198964c60",
+		},
+		{
+			"personalization and additional input",
+			"3aca6b55561521007c9ece085e9a6635e346fa804335d6ad42ebd6814c017fa8",
+			"aa7fd3c3dd5d03d9b8efc7f70574581f",
+			"4bc9a485ec840d377ae4504aa1df41e444c4231687f3d7851c26c275bc687463",
+			[2]string{
+				"b39c43539fdc24343085cbb65b8d36c54732476d781104c355c391a951313a30",
+				"b6850edd4622675ef5a507eab911e249d63fcf62f330cc8a16bb2ccc5858de5d",
+			},
+			[2]string{
+				"4cc19fae5a456f8a53a656d23a0b665d6ddf7f43020a5febbb552714e447565d",
+				"637386b3ab33f78fd9751c7b7e67e1e15f6e50ddc548a1eb5813f6d0d48381bf",
+			},
+			"546664042bef33064da28a5718f2c2e5f72d7725e3fbe87ad2ee90fbfe6c114e" +
+				"d36440fbbccf29698b4360bc4ad74650de13825838106adc53002bc389ee9006" +
+				"91649b972f3187b84d05cecc8fd034497dd99c6c997d1914b4ef838d84abf23f" +
+				"ae7f3ac9efdcdc04c003ac642c5126b00f9f24bf1431a4f19ef0b5f3d230aab3" +
+				"fdf091ba31b7ddcacdf2566f2cfab30f55b3123e733829b697b7c8b248420ab9" +
+				"8ba6f11b017175256368e8d8361102c9e6d57386becbeabda092dd57aec65bc2" +
+				"0ebee78eea7294571e168c454066d256b81bb8b7bb469207a18ebedbb4348fbe" +
+				"97a4d86d2bd095c41f6de59aa0800e131e98181886a2633cdcc550914d83b327",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entropy []byte
+			for _, s := range []string{tt.entropyInput, tt.nonce, tt.entropyPR[0], tt.entropyPR[1]} {
+				entropy = append(entropy, decodeHex(t, s)...)
+			}
+			want := decodeHex(t, tt.returnedBits)
+			for _, pr := range []bool{true, false} {
+				d, err := NewHMAC(sha512.New, Options{
+					Entropy:              bytes.NewReader(entropy),
+					Personalization:      decodeHex(t, tt.personalization),
+					PredictionResistance: pr,
+				})
+				if err != nil {
+					t.Fatalf("NewHMAC() error = %v", err)
+				}
+				got := make([]byte, len(want))
+				// With prediction resistance each request reseeds with its
+				// additional input and then generates without it.
+				for _, add := range tt.additional {
+					additional := decodeHex(t, add)
+					if !pr {
+						if err := d.Reseed(additional); err != nil {
+							t.Fatalf("Reseed() error = %v", err)
+						}
+						additional = nil
+					}
+					if err := d.Generate(got, additional); err != nil {
+						t.Fatalf("Generate() error = %v", err)
+					}
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("prediction resistance %v: Generate() = %x, want %x", pr, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHMAC_Personalization tests that personalization strings separate instances
+func TestHMAC_Personalization(t *testing.T) {
+	generate := func(pers string) []byte {
+		d, err := NewHMAC(nil, Options{Entropy: &countingReader{}, Personalization: []byte(pers)})
+		if err != nil {
+			t.Fatalf("NewHMAC() error = %v", err)
+		}
+		out := make([]byte, 32)
+		if _, err := d.Read(out); err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		return out
+	}
+	if bytes.Equal(generate("a"), generate("b")) {
+		t.Errorf("different personalization strings produced identical output")
+	}
+	if !bytes.Equal(generate("a"), generate("a")) {
+		t.Errorf("identical entropy and personalization produced different output")
+	}
+}
+
+// TestHMAC_Reseed tests reseed intervals and prediction resistance
+func TestHMAC_Reseed(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		generates int
+		wantReads int
+	}{
+		{"default interval", Options{}, 5, 1},
+		{"interval 2", Options{ReseedInterval: 2}, 5, 3},
+		{"prediction resistance", Options{PredictionResistance: true}, 5, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &countingReader{}
+			tt.opts.Entropy = src
+			d, err := NewHMAC(nil, tt.opts)
+			if err != nil {
+				t.Fatalf("NewHMAC() error = %v", err)
+			}
+			out := make([]byte, 16)
+			for range tt.generates {
+				if err := d.Generate(out, nil); err != nil {
+					t.Fatalf("Generate() error = %v", err)
+				}
+			}
+			if src.reads != tt.wantReads {
+				t.Errorf("entropy reads = %d, want %d", src.reads, tt.wantReads)
+			}
+		})
+	}
+}
+
+// TestHMAC_Errors tests option validation and entropy failures
+func TestHMAC_Errors(t *testing.T) {
+	if _, err := NewHMAC(nil, Options{ReseedInterval: MaxReseedInterval + 1}); err == nil {
+		t.Errorf("NewHMAC() with oversized reseed interval error = nil")
+	}
+	if _, err := NewHMAC(nil, Options{Entropy: failingReader{}}); err == nil {
+		t.Errorf("NewHMAC() with failing entropy error = nil")
+	}
+	d, err := NewHMAC(sha512.New, Options{})
+	if err != nil {
+		t.Fatalf("NewHMAC() error = %v", err)
+	}
+	if err := d.Generate(make([]byte, MaxRequestSize+1), nil); err == nil {
+		t.Errorf("Generate() with oversized request error = nil")
+	}
+	if n, err := d.Read(make([]byte, 3*MaxRequestSize+7)); err != nil || n != 3*MaxRequestSize+7 {
+		t.Errorf("Read() = %d, %v, want %d, nil", n, err, 3*MaxRequestSize+7)
+	}
+}
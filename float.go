@@ -0,0 +1,160 @@
+package randutils
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Float64 returns a random float64 in [0, 1).
+// The result is k / 2^53 for a uniform 53-bit k, so every value carries the
+// full 53-bit significand and 1 is never returned, unlike dividing a 64-bit
+// integer by 2^64, which rounds up to 1 and skews the top of the interval.
+func (g *Generator) Float64() (float64, error) {
+	x, err := g.uint64()
+	if err != nil {
+		return 0, err
+	}
+	return float64(x>>11) * 0x1p-53, nil
+}
+
+// Float64Open returns a random float64 in the open interval (0, 1).
+// The result is the midpoint (2k + 1) / 2^53 of one of 2^52 equal cells.
+func (g *Generator) Float64Open() (float64, error) {
+	x, err := g.uint64()
+	if err != nil {
+		return 0, err
+	}
+	return (float64(x>>12) + 0.5) * 0x1p-52, nil
+}
+
+// Float64Closed returns a random float64 in the closed interval [0, 1],
+// uniform over the 2^53 + 1 values k / 2^53.
+func (g *Generator) Float64Closed() (float64, error) {
+	k, err := g.uint64n(1<<53 + 1)
+	if err != nil {
+		return 0, err
+	}
+	return float64(k) * 0x1p-53, nil
+}
+
+// Float64Dense returns a random float64 in [0, 1) that can be any representable
+// value in the interval, including those below 2^-53. Each float x is returned
+// with probability equal to the gap between x and the next float, as if an
+// infinitely precise uniform real were rounded down.
+func (g *Generator) Float64Dense() (float64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// The exponent follows the position of the first set bit of an unbounded
+	// uniform bit string: [0.5, 1) with probability 1/2, [0.25, 0.5) with 1/4...
+	exp := -1
+	for {
+		x, err := g.uint64Locked()
+		if err != nil {
+			return 0, err
+		}
+		if x != 0 {
+			exp -= bits.LeadingZeros64(x)
+			break
+		}
+		exp -= 64
+		if exp < -1074 {
+			return 0, nil
+		}
+	}
+	if exp < -1074 {
+		// Below 2^-1074, the smallest subnormal: the value rounds down to zero.
+		return 0, nil
+	}
+	m, err := g.uint64Locked()
+	if err != nil {
+		return 0, err
+	}
+	if exp < -1022 {
+		// Subnormal range: the spacing is fixed at 2^-1074, so keep only the
+		// mantissa bits that remain representable.
+		m >>= 12 + uint(-1022-exp)
+		return float64(m|1<<(52-uint(-1022-exp))) * 0x1p-1074, nil
+	}
+	return math.Ldexp(1+float64(m>>12)*0x1p-52, exp), nil
+}
+
+// Float32 returns a random float32 in [0, 1) with the full 24-bit significand.
+func (g *Generator) Float32() (float32, error) {
+	x, err := g.uint32()
+	if err != nil {
+		return 0, err
+	}
+	return float32(x>>8) * 0x1p-24, nil
+}
+
+// Float32Open returns a random float32 in the open interval (0, 1).
+func (g *Generator) Float32Open() (float32, error) {
+	x, err := g.uint32()
+	if err != nil {
+		return 0, err
+	}
+	return (float32(x>>9) + 0.5) * 0x1p-23, nil
+}
+
+// Float32Closed returns a random float32 in the closed interval [0, 1],
+// uniform over the 2^24 + 1 values k / 2^24.
+func (g *Generator) Float32Closed() (float32, error) {
+	k, err := g.uint64n(1<<24 + 1)
+	if err != nil {
+		return 0, err
+	}
+	return float32(k) * 0x1p-24, nil
+}
+
+// Float32Dense is the float32 counterpart of Float64Dense.
+func (g *Generator) Float32Dense() (float32, error) {
+	f, err := g.Float64Dense()
+	if err != nil {
+		return 0, err
+	}
+	// Rounding down keeps the round-toward-zero semantics of Float64Dense.
+	r := float32(f)
+	if float64(r) > f {
+		r = math.Nextafter32(r, 0)
+	}
+	return r, nil
+}
+
+// FloatRange returns a random float64 in [min, max) (min inclusive, max exclusive).
+// Both bounds must be finite and min < max; the span may exceed math.MaxFloat64.
+func (g *Generator) FloatRange(min, max float64) (float64, error) {
+	if math.IsNaN(min) || math.IsNaN(max) || math.IsInf(min, 0) || math.IsInf(max, 0) || min >= max {
+		return 0, fmt.Errorf("invalid range: min %v >= max %v", min, max)
+	}
+	for {
+		f, err := g.Float64()
+		if err != nil {
+			return 0, err
+		}
+		// min*(1-f) + max*f cannot overflow even when max-min does.
+		r := min*(1-f) + max*f
+		if max-min <= math.MaxFloat64 {
+			r = min + (max-min)*f
+		}
+		// Rounding can land on max for spans that are not powers of two;
+		// redrawing keeps the interval half-open without biasing the rest.
+		if r >= min && r < max {
+			return r, nil
+		}
+	}
+}
+
+// uint32 returns four bytes of the buffered source as a uint32.
+func (g *Generator) uint32() (uint32, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.uint32Locked()
+}
+
+// uint64 returns eight bytes of the buffered source as a uint64.
+func (g *Generator) uint64() (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.uint64Locked()
+}
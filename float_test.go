@@ -0,0 +1,188 @@
+package randutils
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+// constReader is a source that repeats one byte forever
+type constReader byte
+
+func (c constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(c)
+	}
+	return len(p), nil
+}
+
+// constSource returns a Generator whose source repeats b forever
+func constSource(b byte) *Generator {
+	return New(constReader(b))
+}
+
+// extremeSources returns generators over all-zero, all-one and seeded streams.
+// The closed variants reject the all-zero word forever, so they skip it.
+func extremeSources(closed bool) []*Generator {
+	sources := []*Generator{constSource(0xff), NewSeeded(testSeed())}
+	if !closed {
+		sources = append(sources, constSource(0x00))
+	}
+	return sources
+}
+
+// TestFloat64_Bounds tests every float64 variant against its interval on extreme sources
+func TestFloat64_Bounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		sample  func(g *Generator) (float64, error)
+		lo, hi  float64
+		openLo  bool
+		closeHi bool
+	}{
+		{"Float64", (*Generator).Float64, 0, 1, false, false},
+		{"Float64Open", (*Generator).Float64Open, 0, 1, true, false},
+		{"Float64Closed", (*Generator).Float64Closed, 0, 1, false, true},
+		{"Float64Dense", (*Generator).Float64Dense, 0, 1, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, g := range extremeSources(tt.closeHi) {
+				for range 1000 {
+					v, err := tt.sample(g)
+					if err != nil {
+						t.Fatalf("%s() error = %v", tt.name, err)
+					}
+					if v < tt.lo || v > tt.hi || (tt.openLo && v == tt.lo) || (!tt.closeHi && v == tt.hi) {
+						t.Fatalf("%s() = %v, outside its interval", tt.name, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestFloat32_Bounds tests every float32 variant against its interval on extreme sources
+func TestFloat32_Bounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		sample  func(g *Generator) (float32, error)
+		openLo  bool
+		closeHi bool
+	}{
+		{"Float32", (*Generator).Float32, false, false},
+		{"Float32Open", (*Generator).Float32Open, true, false},
+		{"Float32Closed", (*Generator).Float32Closed, false, true},
+		{"Float32Dense", (*Generator).Float32Dense, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, g := range extremeSources(tt.closeHi) {
+				for range 1000 {
+					v, err := tt.sample(g)
+					if err != nil {
+						t.Fatalf("%s() error = %v", tt.name, err)
+					}
+					if v < 0 || v > 1 || (tt.openLo && v == 0) || (!tt.closeHi && v == 1) {
+						t.Fatalf("%s() = %v, outside its interval", tt.name, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestFloat64_Extremes tests the exact values produced by all-zero and all-one sources
+func TestFloat64_Extremes(t *testing.T) {
+	if v, _ := constSource(0xff).Float64(); v != 1-0x1p-53 {
+		t.Errorf("Float64() on all ones = %v, want 1-2^-53", v)
+	}
+	if v, _ := constSource(0x00).Float64Open(); v != 0x1p-53 {
+		t.Errorf("Float64Open() on all zeros = %v, want 2^-53", v)
+	}
+	if v, _ := constSource(0xff).Float64Dense(); v != math.Nextafter(1, 0) {
+		t.Errorf("Float64Dense() on all ones = %v, want the largest float below 1", v)
+	}
+	if v, _ := constSource(0x00).Float64Dense(); v != 0 {
+		t.Errorf("Float64Dense() on all zeros = %v, want 0", v)
+	}
+}
+
+// TestFloat64Dense_Subnormal tests that the dense sampler reaches tiny exponents exactly
+func TestFloat64Dense_Subnormal(t *testing.T) {
+	// 16 zero words move the exponent to -1025, the next word has its top bit
+	// set and the mantissa word is all ones, giving the largest float below 2^-1024.
+	stream := make([]byte, bufferSize)
+	stream[16*8+7] = 0x80
+	for i := 17 * 8; i < 18*8; i++ {
+		stream[i] = 0xff
+	}
+	v, err := New(bytes.NewReader(stream)).Float64Dense()
+	if err != nil {
+		t.Fatalf("Float64Dense() error = %v", err)
+	}
+	if want := math.Nextafter(0x1p-1024, 0); v != want {
+		t.Errorf("Float64Dense() = %g, want %g", v, want)
+	}
+}
+
+// TestFloat64_Mean tests that the samplers are centred on 1/2
+func TestFloat64_Mean(t *testing.T) {
+	const samples = 100000
+	g := NewSeeded(testSeed())
+	for name, sample := range map[string]func() (float64, error){
+		"Float64":      g.Float64,
+		"Float64Dense": g.Float64Dense,
+		"Float32": func() (float64, error) {
+			v, err := g.Float32()
+			return float64(v), err
+		},
+	} {
+		sum := 0.0
+		for range samples {
+			v, err := sample()
+			if err != nil {
+				t.Fatalf("%s() error = %v", name, err)
+			}
+			sum += v
+		}
+		// The standard error of the mean is sqrt(1/12/samples) ~ 0.0009.
+		if mean := sum / samples; math.Abs(mean-0.5) > 0.005 {
+			t.Errorf("%s() mean = %v, want about 0.5", name, mean)
+		}
+	}
+}
+
+// TestFloatRange tests the FloatRange function
+func TestFloatRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		wantErr  bool
+	}{
+		{"unit", 0, 1, false},
+		{"negative", -10, -5, false},
+		{"tiny span", 1, math.Nextafter(1, 2), false},
+		{"full float span", -math.MaxFloat64, math.MaxFloat64, false},
+		{"equal bounds", 3, 3, true},
+		{"reversed", 5, 3, true},
+		{"NaN bound", math.NaN(), 1, true},
+		{"infinite bound", 0, math.Inf(1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				result, err := FloatRange(tt.min, tt.max)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("FloatRange(%v, %v) error = %v, wantErr %v", tt.min, tt.max, err, tt.wantErr)
+				}
+				if !tt.wantErr && (result < tt.min || result >= tt.max) {
+					t.Fatalf("FloatRange(%v, %v) = %v, want result in range [%v, %v)", tt.min, tt.max, result, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package randutils
+
+import (
+	crand "crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+	"sync"
+
+	"github.com/chaosoffire/go-randutils/models"
+	"github.com/chaosoffire/go-randutils/uuid"
+)
+
+// Generator produces random values from an arbitrary source of random bytes.
+// It exposes every package-level function as a method, so callers can inject
+// a deterministic stream in tests or a hardware-backed source in production.
+// A Generator is safe for concurrent use if its source is.
+//
+// Integer sampling reads the source through an internal buffer, so small
+// draws do not cost one read (or syscall) each. A single read from the
+// source may therefore serve many integers, characters or floats. Sources
+// that must be read once per value, such as a DRBG with prediction
+// resistance, should use NewUnbuffered, or Byte and Read, which always read
+// the source directly.
+//
+// The zero value is a buffered Generator reading from crypto/rand.
+type Generator struct {
+	source     io.Reader
+	unbuffered bool // read the source once per draw instead of through buf
+
+	mu  sync.Mutex
+	buf [bufferSize]byte
+	n   int // number of unread bytes at the end of buf
+}
+
+// bufferSize is the number of source bytes fetched at once for integer sampling.
+const bufferSize = 512
+
+// defaultGenerator backs the package-level functions with crypto/rand.
+var defaultGenerator = New(crand.Reader)
+
+// New returns a Generator that reads its randomness from source.
+// If source is nil, crypto/rand.Reader is used.
+func New(source io.Reader) *Generator {
+	if source == nil {
+		source = crand.Reader
+	}
+	return &Generator{source: source}
+}
+
+// NewUnbuffered is like New but reads the source for every random draw,
+// fetching exactly the bytes each integer, character or float consumes.
+// It is slower than New for small values; use it when every value must come
+// from a fresh read, as with a drbg source with PredictionResistance.
+func NewUnbuffered(source io.Reader) *Generator {
+	g := New(source)
+	g.unbuffered = true
+	return g
+}
+
+// reader returns the source of g, or crypto/rand.Reader for a zero Generator.
+func (g *Generator) reader() io.Reader {
+	if g.source == nil {
+		return crand.Reader
+	}
+	return g.source
+}
+
+// Default returns the crypto/rand backed Generator used by the package-level functions.
+func Default() *Generator {
+	return defaultGenerator
+}
+
+// Int returns a random integer in the range [0, max) (min inclusive, max exclusive).
+// It returns an error if max <= 0 or if the source fails.
+func (g *Generator) Int(max int) (int, error) {
+	return IntNWith(g, max)
+}
+
+// IntRange returns a random integer in the range [min, max) (min inclusive, max exclusive).
+// Any range with min < max is valid, including one spanning the full int domain.
+func (g *Generator) IntRange(min, max int) (int, error) {
+	return RangeOfWith(g, min, max)
+}
+
+// Int64Range returns a random int64 in the range [min, max) (min inclusive, max exclusive).
+// Any range with min < max is valid, including [math.MinInt64, math.MaxInt64).
+func (g *Generator) Int64Range(min, max int64) (int64, error) {
+	return RangeOfWith(g, min, max)
+}
+
+// Uint64Range returns a random uint64 in the range [min, max) (min inclusive, max exclusive).
+// Any range with min < max is valid, including [0, math.MaxUint64).
+func (g *Generator) Uint64Range(min, max uint64) (uint64, error) {
+	return RangeOfWith(g, min, max)
+}
+
+// uint64n returns a uniform random value in [0, n). n must be greater than zero.
+func (g *Generator) uint64n(n uint64) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.uint64nLocked(n)
+}
+
+// uint64nLocked implements uint64n using Lemire's nearly divisionless method
+// ("Fast Random Integer Generation in an Interval", 2019). The product of a
+// uniform word and n is split into high and low halves; the high half is
+// uniform in [0, n) once low halves below 2^w mod n are rejected, which
+// keeps the result exactly uniform while rarely paying for a division.
+// Bounds up to 2^32 consume four bytes per attempt, larger bounds eight.
+// The caller must hold g.mu.
+func (g *Generator) uint64nLocked(n uint64) (uint64, error) {
+	if n <= 1<<32 {
+		x, err := g.uint32Locked()
+		if err != nil {
+			return 0, err
+		}
+		// For n == 2^32 the threshold is zero and x is returned unchanged.
+		m := uint64(x) * n
+		if uint32(m) < uint32(n) {
+			thresh := uint32(-n) % uint32(n)
+			for uint32(m) < thresh {
+				if x, err = g.uint32Locked(); err != nil {
+					return 0, err
+				}
+				m = uint64(x) * n
+			}
+		}
+		return m >> 32, nil
+	}
+	x, err := g.uint64Locked()
+	if err != nil {
+		return 0, err
+	}
+	hi, lo := bits.Mul64(x, n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			if x, err = g.uint64Locked(); err != nil {
+				return 0, err
+			}
+			hi, lo = bits.Mul64(x, n)
+		}
+	}
+	return hi, nil
+}
+
+// uint32Locked returns four bytes of the buffered source as a uint32.
+// The caller must hold g.mu.
+func (g *Generator) uint32Locked() (uint32, error) {
+	b, err := g.nextLocked(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// uint64Locked returns eight bytes of the buffered source as a uint64.
+// The caller must hold g.mu.
+func (g *Generator) uint64Locked() (uint64, error) {
+	b, err := g.nextLocked(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// nextLocked returns the next n unread bytes of the buffer, refilling it from
+// the source when fewer than n remain, or n bytes read directly from the
+// source if g is unbuffered. n must divide bufferSize.
+// The caller must hold g.mu.
+func (g *Generator) nextLocked(n int) ([]byte, error) {
+	if g.unbuffered {
+		if _, err := io.ReadFull(g.reader(), g.buf[:n]); err != nil {
+			return nil, fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		return g.buf[:n], nil
+	}
+	if g.n < n {
+		if _, err := io.ReadFull(g.reader(), g.buf[:]); err != nil {
+			g.n = 0
+			return nil, fmt.Errorf("failed to read random bytes: %w", err)
+		}
+		g.n = len(g.buf)
+	}
+	off := len(g.buf) - g.n
+	g.n -= n
+	return g.buf[off : off+n], nil
+}
+
+// Random generates a random sequence of integers by selecting from the provided charset.
+// Every element of charset is selected with equal probability.
+// Use RandomFrom or RandomRunes to select from a models.RuneSet.
+func (g *Generator) Random(length int, charset []int) ([]int, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+	lengthSet := len(charset)
+	if lengthSet == 0 {
+		return nil, fmt.Errorf("charset is empty")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b := make([]int, 0, length)
+	for range length {
+		idx, err := g.uint64nLocked(uint64(lengthSet))
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, charset[idx])
+	}
+	return b, nil
+}
+
+// RandomFrom generates a random sequence of code points by selecting from charset.
+// Every member of charset is selected with equal probability.
+func (g *Generator) RandomFrom(length int, charset models.RuneSet) ([]int, error) {
+	runes, err := g.RandomRunes(length, charset)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]int, len(runes))
+	for i, r := range runes {
+		b[i] = int(r)
+	}
+	return b, nil
+}
+
+// RandomRunes generates a random sequence of runes by selecting from charset.
+// Every member of charset is selected with equal probability.
+func (g *Generator) RandomRunes(length int, charset models.RuneSet) ([]rune, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+	if charset.Len() == 0 {
+		return nil, fmt.Errorf("charset is empty")
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b := make([]rune, 0, length)
+	for range length {
+		r, err := g.runeLocked(charset)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, r)
+	}
+	return b, nil
+}
+
+// runeLocked returns a uniformly chosen member of the non-empty charset.
+// The caller must hold g.mu.
+func (g *Generator) runeLocked(charset models.RuneSet) (rune, error) {
+	idx, err := g.uint64nLocked(uint64(charset.Len()))
+	if err != nil {
+		return 0, err
+	}
+	return charset.At(int(idx)), nil
+}
+
+// Strings generates a random string of specified length using alphanumeric characters (A-Z, a-z, 0-9).
+// Use StringsFrom to choose the characters from a models.RuneSet.
+func (g *Generator) Strings(length int) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("invalid length: %d", length)
+	}
+	randomInts, err := g.Random(length, models.Charset)
+	if err != nil {
+		return "", err
+	}
+	return toASCII(randomInts), nil
+}
+
+// StringsFrom generates a random string of length characters selected from charset.
+// Characters are counted as code points, so the result may be longer than length bytes.
+func (g *Generator) StringsFrom(length int, charset models.RuneSet) (string, error) {
+	runes, err := g.RandomRunes(length, charset)
+	if err != nil {
+		return "", err
+	}
+	return string(runes), nil
+}
+
+// Byte generates a random byte slice of specified length read from the source.
+func (g *Generator) Byte(length int) ([]byte, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+	result := make([]byte, length)
+	_, err := io.ReadFull(g.reader(), result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return result, nil
+}
+
+// Read fills p with bytes read directly from the source, so a Generator can
+// be passed wherever an io.Reader is expected, such as uuid.NewV4 or
+// uuid.V7Options. It always fills p completely or returns an error.
+func (g *Generator) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(g.reader(), p)
+	if err != nil {
+		return n, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return n, nil
+}
+
+// Base64 generates a random base64-encoded string from length random bytes.
+func (g *Generator) Base64(length int) (string, error) {
+	result, err := g.Byte(length)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(result), nil
+}
+
+// Hex generates a random hexadecimal string from length random bytes.
+func (g *Generator) Hex(length int) (string, error) {
+	result, err := g.Byte(length)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", result), nil
+}
+
+// UUID generates a random RFC 9562 (formerly RFC 4122) version 4 UUID in
+// canonical form. See the uuid package for the UUID type and other versions.
+func (g *Generator) UUID() (string, error) {
+	id, err := uuid.NewV4(g.reader())
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// AllChars generates a random string of specified length using digits, letters and
+// every printable ASCII symbol, i.e. all printable ASCII characters except space.
+func (g *Generator) AllChars(length int) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("invalid length: %d", length)
+	}
+	randomInts, err := g.Random(length, models.Allset)
+	if err != nil {
+		return "", err
+	}
+	return toASCII(randomInts), nil
+}
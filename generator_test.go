@@ -0,0 +1,285 @@
+package randutils
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// errSource is a source that always fails.
+type errSource struct{}
+
+func (errSource) Read([]byte) (int, error) {
+	return 0, errors.New("source failure")
+}
+
+// counterSource is a deterministic source yielding 0, 1, 2, ... as bytes.
+type counterSource struct {
+	next byte
+}
+
+func (c *counterSource) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = c.next
+		c.next++
+	}
+	return len(p), nil
+}
+
+// TestNew_NilSource tests that New falls back to crypto/rand for a nil source
+func TestNew_NilSource(t *testing.T) {
+	g := New(nil)
+	if _, err := g.Byte(16); err != nil {
+		t.Fatalf("Byte() error = %v", err)
+	}
+}
+
+// TestGenerator_ZeroValue tests that a zero Generator draws from crypto/rand
+// instead of serving its empty buffer
+func TestGenerator_ZeroValue(t *testing.T) {
+	var g Generator
+	seen := make(map[int]bool)
+	for range 8 {
+		n, err := g.Int(1 << 20)
+		if err != nil {
+			t.Fatalf("Int() error = %v", err)
+		}
+		seen[n] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Int() on a zero Generator returned one value 8 times: %v", seen)
+	}
+	token, err := g.Random(20, models.Allset)
+	if err != nil {
+		t.Fatalf("Random() error = %v", err)
+	}
+	if toASCII(token) == strings.Repeat(string(rune(token[0])), 20) {
+		t.Errorf("Random() on a zero Generator = %q, want varied characters", toASCII(token))
+	}
+	for _, f := range []func() error{
+		func() error { _, err := g.Byte(16); return err },
+		func() error { _, err := g.UUID(); return err },
+		func() error { _, err := g.BigInt(big.NewInt(1000)); return err },
+	} {
+		if err := f(); err != nil {
+			t.Errorf("zero Generator error = %v", err)
+		}
+	}
+}
+
+// TestDefault tests that Default returns the generator behind the package-level functions
+func TestDefault(t *testing.T) {
+	if Default() != defaultGenerator {
+		t.Errorf("Default() did not return the package-level generator")
+	}
+}
+
+// TestGenerator_Deterministic tests that identical sources produce identical output
+func TestGenerator_Deterministic(t *testing.T) {
+	g1 := New(&counterSource{})
+	g2 := New(&counterSource{})
+
+	s1, err := g1.Strings(32)
+	if err != nil {
+		t.Fatalf("Strings() error = %v", err)
+	}
+	s2, err := g2.Strings(32)
+	if err != nil {
+		t.Fatalf("Strings() error = %v", err)
+	}
+	if s1 != s2 {
+		t.Errorf("Strings() = %q and %q, want identical output", s1, s2)
+	}
+
+	b, err := New(&counterSource{next: 7}).Byte(4)
+	if err != nil {
+		t.Fatalf("Byte() error = %v", err)
+	}
+	if !bytes.Equal(b, []byte{7, 8, 9, 10}) {
+		t.Errorf("Byte() = %v, want [7 8 9 10]", b)
+	}
+}
+
+// TestGenerator_SourceError tests that source failures are reported by every method
+func TestGenerator_SourceError(t *testing.T) {
+	g := New(errSource{})
+	calls := map[string]func() error{
+		"Int":         func() error { _, err := g.Int(10); return err },
+		"IntRange":    func() error { _, err := g.IntRange(1, 10); return err },
+		"Random":      func() error { _, err := g.Random(5, []int{1, 2, 3}); return err },
+		"Strings":     func() error { _, err := g.Strings(5); return err },
+		"StringsFrom": func() error { _, err := g.StringsFrom(5, models.AlnumChars); return err },
+		"Byte":        func() error { _, err := g.Byte(5); return err },
+		"Base64":      func() error { _, err := g.Base64(5); return err },
+		"Hex":         func() error { _, err := g.Hex(5); return err },
+		"UUID":        func() error { _, err := g.UUID(); return err },
+		"Read":        func() error { _, err := g.Read(make([]byte, 5)); return err },
+		"AllChars":    func() error { _, err := g.AllChars(5); return err },
+		"FromRegex":   func() error { _, err := g.FromRegex(`[a-z]{5}`); return err },
+		"FromMask":    func() error { _, err := g.FromMask("AAA-999"); return err },
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); err == nil {
+				t.Errorf("%s() error = nil, want source failure", name)
+			}
+		})
+	}
+}
+
+// TestGenerator_UUID tests that UUID sets the version and variant bits on any source
+func TestGenerator_UUID(t *testing.T) {
+	result, err := New(bytes.NewReader(bytes.Repeat([]byte{0xff}, 16))).UUID()
+	if err != nil {
+		t.Fatalf("UUID() error = %v", err)
+	}
+	if want := "ffffffff-ffff-4fff-bfff-ffffffffffff"; result != want {
+		t.Errorf("UUID() = %s, want %s", result, want)
+	}
+}
+
+// TestGenerator_Read tests that Read fills the buffer from the source
+func TestGenerator_Read(t *testing.T) {
+	g := New(&counterSource{})
+	p := make([]byte, 1000)
+	n, err := g.Read(p)
+	if err != nil || n != len(p) {
+		t.Fatalf("Read() = %d, %v; want %d, nil", n, err, len(p))
+	}
+	for i, b := range p {
+		if b != byte(i) {
+			t.Fatalf("Read() byte %d = %d, want %d", i, b, byte(i))
+		}
+	}
+	if _, err := New(bytes.NewReader(make([]byte, 3))).Read(make([]byte, 4)); err == nil {
+		t.Errorf("Read() past the end of the source error = nil, want error")
+	}
+}
+
+// readCounter counts the reads and bytes requested from its source.
+type readCounter struct {
+	src          counterSource
+	reads, bytes int
+}
+
+func (r *readCounter) Read(p []byte) (int, error) {
+	r.reads++
+	r.bytes += len(p)
+	return r.src.Read(p)
+}
+
+// TestNewUnbuffered tests that an unbuffered Generator reads only the bytes each draw consumes
+func TestNewUnbuffered(t *testing.T) {
+	src := &readCounter{}
+	g := NewUnbuffered(src)
+	for range 10 {
+		if _, err := g.Uint64Range(0, math.MaxUint64); err != nil {
+			t.Fatalf("Uint64Range() error = %v", err)
+		}
+	}
+	if src.reads != 10 || src.bytes != 80 {
+		t.Errorf("10 draws read %d bytes in %d reads, want 80 bytes in 10 reads", src.bytes, src.reads)
+	}
+	if _, err := g.Strings(16); err != nil {
+		t.Fatalf("Strings() error = %v", err)
+	}
+	if src.reads < 26 || src.bytes >= bufferSize {
+		t.Errorf("Strings(16) read %d bytes in %d reads, want one read per character", src.bytes-80, src.reads-10)
+	}
+
+	buffered := &readCounter{}
+	g = New(buffered)
+	for range 10 {
+		if _, err := g.Uint64Range(0, math.MaxUint64); err != nil {
+			t.Fatalf("Uint64Range() error = %v", err)
+		}
+	}
+	if buffered.reads != 1 {
+		t.Errorf("buffered generator made %d reads, want 1", buffered.reads)
+	}
+}
+
+// TestGenerator_Rejection tests that Lemire sampling rejects the biased low products
+func TestGenerator_Rejection(t *testing.T) {
+	// For n = 3 the 32-bit threshold is 2^32 mod 3 = 1: the zero word is
+	// rejected and the all-ones word maps to the top bucket.
+	stream := make([]byte, bufferSize)
+	copy(stream[4:], []byte{0xff, 0xff, 0xff, 0xff})
+	result, err := New(bytes.NewReader(stream)).Int(3)
+	if err != nil {
+		t.Fatalf("Int() error = %v", err)
+	}
+	if result != 2 {
+		t.Errorf("Int(3) = %d, want 2", result)
+	}
+}
+
+// TestGenerator_Uniform runs a chi-square test on Int for bounds that do not divide 2^32
+func TestGenerator_Uniform(t *testing.T) {
+	const samples = 60000
+	g := NewSeeded(testSeed())
+	for _, n := range []int{3, 7, 62, 94} {
+		counts := make([]int, n)
+		for range samples {
+			v, err := g.Int(n)
+			if err != nil {
+				t.Fatalf("Int() error = %v", err)
+			}
+			counts[v]++
+		}
+		expected := float64(samples) / float64(n)
+		chi2 := 0.0
+		for _, c := range counts {
+			d := float64(c) - expected
+			chi2 += d * d / expected
+		}
+		// Wilson-Hilferty approximation of the 0.999 quantile for n-1 degrees of freedom
+		k := float64(n - 1)
+		limit := k * math.Pow(1-2/(9*k)+3.09*math.Sqrt(2/(9*k)), 3)
+		if chi2 > limit {
+			t.Errorf("Int(%d) chi-square = %.2f, want <= %.2f (counts %v)", n, chi2, limit, counts)
+		}
+	}
+}
+
+// TestGenerator_LargeBounds tests the 64-bit sampling path
+func TestGenerator_LargeBounds(t *testing.T) {
+	g := NewSeeded(testSeed())
+	for _, n := range []uint64{1<<32 + 1, 1<<63 + 5, math.MaxUint64} {
+		for range 100 {
+			v, err := g.Uint64Range(0, n)
+			if err != nil {
+				t.Fatalf("Uint64Range() error = %v", err)
+			}
+			if v >= n {
+				t.Fatalf("Uint64Range(0, %d) = %d, out of range", n, v)
+			}
+		}
+	}
+}
+
+// TestGenerator_Concurrent tests that a shared Generator can be used from many goroutines
+func TestGenerator_Concurrent(t *testing.T) {
+	g := NewSeeded(testSeed())
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 200 {
+				if _, err := g.Strings(16); err != nil {
+					t.Errorf("Strings() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,88 @@
+package randutils
+
+import "fmt"
+
+// Integer is the set of all signed and unsigned integer types,
+// including named types whose underlying type is an integer.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is the set of floating-point types,
+// including named types whose underlying type is a float.
+type Float interface {
+	~float32 | ~float64
+}
+
+// IntN returns a random value of type T in the range [0, n).
+// It returns an error if n <= 0.
+func IntN[T Integer](n T) (T, error) {
+	return IntNWith(defaultGenerator, n)
+}
+
+// IntNWith is like IntN but draws from g.
+func IntNWith[T Integer](g *Generator, n T) (T, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid max: %d", n)
+	}
+	result, err := g.uint64n(uint64(n))
+	if err != nil {
+		return 0, err
+	}
+	return T(result), nil
+}
+
+// RangeOf returns a random value of type T in the range [min, max) (min inclusive, max exclusive).
+// Any range with min < max is valid, including the full domain of T minus its maximum.
+func RangeOf[T Integer](min, max T) (T, error) {
+	return RangeOfWith(defaultGenerator, min, max)
+}
+
+// RangeOfWith is like RangeOf but draws from g.
+func RangeOfWith[T Integer](g *Generator, min, max T) (T, error) {
+	if min >= max {
+		return 0, fmt.Errorf("invalid range: min %d >= max %d", min, max)
+	}
+	result, err := g.uint64n(span(min, max))
+	if err != nil {
+		return 0, err
+	}
+	return T(uint64(min) + result), nil
+}
+
+// SliceOf returns length random values of type T, each in the range [min, max).
+// It returns an error if length <= 0 or min >= max.
+func SliceOf[T Integer](length int, min, max T) ([]T, error) {
+	return SliceOfWith(defaultGenerator, length, min, max)
+}
+
+// SliceOfWith is like SliceOf but draws from g.
+func SliceOfWith[T Integer](g *Generator, length int, min, max T) ([]T, error) {
+	if length <= 0 {
+		return nil, fmt.Errorf("invalid length: %d", length)
+	}
+	if min >= max {
+		return nil, fmt.Errorf("invalid range: min %d >= max %d", min, max)
+	}
+	n := span(min, max)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	result := make([]T, length)
+	for i := range result {
+		v, err := g.uint64nLocked(n)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = T(uint64(min) + v)
+	}
+	return result, nil
+}
+
+// span returns max - min for min < max as a uint64. Converting a signed value
+// to uint64 sign-extends it, so the wrapped subtraction is exact for every
+// integer width, and adding a value in [0, span) back to uint64(min) and
+// truncating to T lands in [min, max).
+func span[T Integer](min, max T) uint64 {
+	return uint64(max) - uint64(min)
+}
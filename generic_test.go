@@ -0,0 +1,156 @@
+package randutils
+
+import (
+	"math"
+	"testing"
+)
+
+// TestIntN tests the IntN function across integer widths
+func TestIntN(t *testing.T) {
+	if v, err := IntN[uint8](255); err != nil || v >= 255 {
+		t.Errorf("IntN[uint8](255) = %d, %v", v, err)
+	}
+	if v, err := IntN[int16](math.MaxInt16); err != nil || v < 0 || v >= math.MaxInt16 {
+		t.Errorf("IntN[int16](MaxInt16) = %d, %v", v, err)
+	}
+	if v, err := IntN[uint64](math.MaxUint64); err != nil || v >= math.MaxUint64 {
+		t.Errorf("IntN[uint64](MaxUint64) = %d, %v", v, err)
+	}
+	if _, err := IntN[int8](0); err == nil {
+		t.Errorf("IntN[int8](0) error = nil, want error")
+	}
+	if _, err := IntN[int32](-1); err == nil {
+		t.Errorf("IntN[int32](-1) error = nil, want error")
+	}
+}
+
+// TestRangeOf tests RangeOf at the boundaries of every integer width
+func TestRangeOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func() (ok bool, err error)
+	}{
+		{"int8 full span", func() (bool, error) {
+			v, err := RangeOf[int8](math.MinInt8, math.MaxInt8)
+			return v >= math.MinInt8 && v < math.MaxInt8, err
+		}},
+		{"uint8 full span", func() (bool, error) {
+			v, err := RangeOf[uint8](0, math.MaxUint8)
+			return v < math.MaxUint8, err
+		}},
+		{"int16 top", func() (bool, error) {
+			v, err := RangeOf[int16](math.MaxInt16-1, math.MaxInt16)
+			return v == math.MaxInt16-1, err
+		}},
+		{"uint16 top", func() (bool, error) {
+			v, err := RangeOf[uint16](math.MaxUint16-1, math.MaxUint16)
+			return v == math.MaxUint16-1, err
+		}},
+		{"int32 bottom", func() (bool, error) {
+			v, err := RangeOf[int32](math.MinInt32, math.MinInt32+1)
+			return v == math.MinInt32, err
+		}},
+		{"uint32 full span", func() (bool, error) {
+			v, err := RangeOf[uint32](0, math.MaxUint32)
+			return v < math.MaxUint32, err
+		}},
+		{"int64 full span", func() (bool, error) {
+			_, err := RangeOf[int64](math.MinInt64, math.MaxInt64)
+			return true, err
+		}},
+		{"uint64 top", func() (bool, error) {
+			v, err := RangeOf[uint64](math.MaxUint64-1, math.MaxUint64)
+			return v == math.MaxUint64-1, err
+		}},
+		{"uintptr", func() (bool, error) {
+			v, err := RangeOf[uintptr](10, 20)
+			return v >= 10 && v < 20, err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := tt.check()
+			if err != nil {
+				t.Fatalf("RangeOf() error = %v", err)
+			}
+			if !ok {
+				t.Errorf("RangeOf() returned a value outside the requested range")
+			}
+		})
+	}
+}
+
+// TestRangeOf_Invalid tests that empty ranges are rejected
+func TestRangeOf_Invalid(t *testing.T) {
+	if _, err := RangeOf[int8](5, 5); err == nil {
+		t.Errorf("RangeOf[int8](5, 5) error = nil, want error")
+	}
+	if _, err := RangeOf[uint16](10, 1); err == nil {
+		t.Errorf("RangeOf[uint16](10, 1) error = nil, want error")
+	}
+}
+
+// TestRangeOf_Coverage tests that every value of a small signed range is produced
+func TestRangeOf_Coverage(t *testing.T) {
+	g := NewSeeded(testSeed())
+	seen := make(map[int8]bool)
+	for range 20000 {
+		v, err := RangeOfWith[int8](g, math.MinInt8, math.MaxInt8)
+		if err != nil {
+			t.Fatalf("RangeOfWith() error = %v", err)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 255 {
+		t.Errorf("RangeOfWith[int8] produced %d distinct values, want 255", len(seen))
+	}
+}
+
+// TestRangeOf_NamedType tests that named integer types are accepted
+func TestRangeOf_NamedType(t *testing.T) {
+	type port uint16
+	v, err := RangeOf[port](1024, 65535)
+	if err != nil {
+		t.Fatalf("RangeOf[port]() error = %v", err)
+	}
+	if v < 1024 {
+		t.Errorf("RangeOf[port]() = %d, want >= 1024", v)
+	}
+}
+
+// TestSliceOf tests the SliceOf function
+func TestSliceOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   int
+		min, max int32
+		wantErr  bool
+	}{
+		{"valid", 100, -3, 3, false},
+		{"single value", 5, 7, 8, false},
+		{"full span", 10, math.MinInt32, math.MaxInt32, false},
+		{"invalid length", 0, 0, 10, true},
+		{"invalid range", 10, 3, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SliceOf(tt.length, tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SliceOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(result) != tt.length {
+				t.Errorf("SliceOf() length = %d, want %d", len(result), tt.length)
+			}
+			for _, v := range result {
+				if v < tt.min || v >= tt.max {
+					t.Errorf("SliceOf() value %d outside [%d, %d)", v, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
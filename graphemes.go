@@ -0,0 +1,120 @@
+package randutils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// GraphemeOptions configures StringsGraphemes.
+type GraphemeOptions struct {
+	// MaxMarks is the maximum number of combining marks attached to each
+	// cluster; each cluster gets a uniform count in [0, MaxMarks].
+	// Zero produces clusters of a single code point.
+	MaxMarks int
+	// Marks is the set of combining marks to attach. It must contain only
+	// characters of category M. Defaults to models.CombiningChars.
+	Marks models.RuneSet
+}
+
+var (
+	// markChars contains every combining mark (category M).
+	markChars = models.NewRuneSetTable(unicode.M)
+
+	// clusterJoiners contains the characters that can extend or join an
+	// adjacent grapheme cluster under the rules of UAX #29, and so never
+	// start a generated cluster: marks, controls and format characters
+	// (including ZWJ), and the characters of joinerTable.
+	clusterJoiners = markChars.Union(models.NewRuneSetTable(unicode.C)).Union(models.NewRuneSetTable(joinerTable))
+
+	// linkers are the viramas that join consonant clusters (UAX #29 rule GB9c).
+	linkers = models.NewRuneSet("\u094D\u09CD\u0ACD\u0B4D\u0C4D\u0D4D")
+)
+
+// StringsGraphemes generates a string of count user-perceived characters
+// (extended grapheme clusters, UAX #29) rather than count bytes or code
+// points, for fuzzing text handling in internationalized code.
+//
+// Each cluster is a base character drawn uniformly from charset, followed
+// by up to opts.MaxMarks combining marks. Characters of charset that could
+// merge with a neighbouring cluster (marks, controls, ZWJ, Hangul jamo,
+// regional indicators, emoji modifiers) are never used as bases, and
+// consonant-joining viramas are never used as marks, so the result always
+// segments into exactly count clusters.
+// It returns an error if count <= 0, opts.MaxMarks < 0, opts.Marks contains
+// a non-mark, or charset has no usable base characters.
+func (g *Generator) StringsGraphemes(count int, charset models.RuneSet, opts GraphemeOptions) (string, error) {
+	if count <= 0 {
+		return "", fmt.Errorf("invalid length: %d", count)
+	}
+	if opts.MaxMarks < 0 {
+		return "", fmt.Errorf("invalid mark count: %d", opts.MaxMarks)
+	}
+	bases := charset.Subtract(clusterJoiners)
+	if bases.Len() == 0 {
+		return "", fmt.Errorf("charset has no grapheme cluster bases")
+	}
+	marks := opts.Marks
+	if marks.Len() == 0 {
+		marks = models.CombiningChars
+	}
+	if marks.Subtract(markChars).Len() != 0 {
+		return "", fmt.Errorf("marks contain non-combining characters")
+	}
+	marks = marks.Subtract(linkers)
+	if marks.Len() == 0 && opts.MaxMarks > 0 {
+		return "", fmt.Errorf("marks contain only consonant linkers")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var sb strings.Builder
+	for range count {
+		r, err := g.runeLocked(bases)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteRune(r)
+		if opts.MaxMarks == 0 {
+			continue
+		}
+		n, err := g.uint64nLocked(uint64(opts.MaxMarks) + 1)
+		if err != nil {
+			return "", err
+		}
+		for range n {
+			if r, err = g.runeLocked(marks); err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String(), nil
+}
+
+// joinerTable lists the joining characters outside categories M and C:
+// Hangul jamo, regional indicators, emoji modifiers, halfwidth sound marks,
+// and the letters with the Prepend or SpacingMark property.
+var joinerTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0D4E, Hi: 0x0D4E, Stride: 1}, // MALAYALAM LETTER DOT REPH (Prepend)
+		{Lo: 0x0E33, Hi: 0x0E33, Stride: 1}, // THAI CHARACTER SARA AM (SpacingMark)
+		{Lo: 0x0EB3, Hi: 0x0EB3, Stride: 1}, // LAO VOWEL SIGN AM (SpacingMark)
+		{Lo: 0x1100, Hi: 0x11FF, Stride: 1}, // Hangul Jamo
+		{Lo: 0xA960, Hi: 0xA97F, Stride: 1}, // Hangul Jamo Extended-A
+		{Lo: 0xD7B0, Hi: 0xD7FF, Stride: 1}, // Hangul Jamo Extended-B
+		{Lo: 0xFF9E, Hi: 0xFF9F, Stride: 1}, // halfwidth katakana sound marks
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x111C2, Hi: 0x111C3, Stride: 1}, // Prepend letters
+		{Lo: 0x1193F, Hi: 0x11941, Stride: 2},
+		{Lo: 0x11A3A, Hi: 0x11A3A, Stride: 1},
+		{Lo: 0x11A84, Hi: 0x11A89, Stride: 1},
+		{Lo: 0x11D46, Hi: 0x11D46, Stride: 1},
+		{Lo: 0x11F02, Hi: 0x11F02, Stride: 1},
+		{Lo: 0x1F1E6, Hi: 0x1F1FF, Stride: 1}, // regional indicators
+		{Lo: 0x1F3FB, Hi: 0x1F3FF, Stride: 1}, // emoji modifiers
+	},
+}
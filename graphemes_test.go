@@ -0,0 +1,148 @@
+package randutils
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// TestStringsGraphemes tests that StringsGraphemes produces the requested number of clusters
+func TestStringsGraphemes(t *testing.T) {
+	// A charset mixing safe bases with characters that would join clusters:
+	// regional indicators, Hangul jamo, ZWJ and a combining mark.
+	mixed := models.GreekChars.
+		Union(models.NewRuneSet("\U0001F1E6\U0001F1E8\u1100\u1161\u200D\u0301\r\n"))
+	tests := []struct {
+		name    string
+		count   int
+		charset models.RuneSet
+		opts    GraphemeOptions
+	}{
+		{"bare bases", 50, models.CyrillicChars, GraphemeOptions{}},
+		{"default marks", 50, models.AlphabetChars, GraphemeOptions{MaxMarks: 3}},
+		{"custom marks", 50, models.CJKChars, GraphemeOptions{MaxMarks: 2, Marks: models.NewRuneSet("\u0300\u0301\u093C")}},
+		{"joiners filtered", 200, mixed, GraphemeOptions{MaxMarks: 1}},
+		{"emoji", 20, models.EmojiChars, GraphemeOptions{}},
+	}
+
+	g := NewSeeded(testSeed())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := g.StringsGraphemes(tt.count, tt.charset, tt.opts)
+			if err != nil {
+				t.Fatalf("StringsGraphemes() error = %v", err)
+			}
+			clusters := splitMarks(result)
+			if len(clusters) != tt.count {
+				t.Errorf("StringsGraphemes() produced %d clusters, want %d", len(clusters), tt.count)
+			}
+			for _, cluster := range clusters {
+				base := cluster[0]
+				if !tt.charset.Contains(base) || unicode.Is(unicode.M, base) || unicode.Is(unicode.C, base) ||
+					unicode.Is(unicode.Regional_Indicator, base) {
+					t.Fatalf("StringsGraphemes() = %q, invalid base %U", result, base)
+				}
+				if len(cluster)-1 > tt.opts.MaxMarks {
+					t.Fatalf("StringsGraphemes() = %q, more than %d marks in a cluster", result, tt.opts.MaxMarks)
+				}
+			}
+		})
+	}
+}
+
+// splitMarks splits s into clusters of a non-mark followed by the marks
+// (category M) after it
+func splitMarks(s string) [][]rune {
+	var clusters [][]rune
+	for _, r := range s {
+		if unicode.Is(unicode.M, r) && len(clusters) > 0 {
+			clusters[len(clusters)-1] = append(clusters[len(clusters)-1], r)
+			continue
+		}
+		clusters = append(clusters, []rune{r})
+	}
+	return clusters
+}
+
+// TestClusterJoiners tests the joining characters against hand-segmented
+// UAX #29 clusters: within each cluster, every character must be one
+// StringsGraphemes never uses as a base, or follow a character it never emits
+func TestClusterJoiners(t *testing.T) {
+	tests := []struct {
+		name     string
+		clusters []string
+	}{
+		{"regional indicator pairs", []string{"\U0001F1E6\U0001F1E8", "\U0001F1E9\U0001F1EA"}},
+		{"ZWJ sequence", []string{"\U0001F469\u200D\U0001F4BB", "a"}},
+		{"emoji modifier", []string{"\U0001F44D\U0001F3FD", "\U0001F44D"}},
+		{"Hangul jamo", []string{"\u1100\u1161\u11A8", "\uAC00", "\uA960\uD7B0"}},
+		{"combining marks", []string{"e\u0327\u0301", "a"}},
+		{"virama conjunct", []string{"\u0915\u094D\u0937", "\u0915"}},
+		{"prepend and spacing marks", []string{"\u0D4E\u0D15", "\u0E01\u0E33", "\uFF76\uFF9E"}},
+		{"CR LF", []string{"\r\n", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cluster := range tt.clusters {
+				runes := []rune(cluster)
+				for i := 1; i < len(runes); i++ {
+					prev, r := runes[i-1], runes[i]
+					// Marks may precede a new base; other joiners and
+					// linkers are never emitted before one.
+					if !clusterJoiners.Contains(r) && !linkers.Contains(prev) &&
+						!(clusterJoiners.Contains(prev) && !markChars.Contains(prev)) {
+						t.Errorf("cluster %q: %U after %U could start a new cluster", cluster, r, prev)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestStringsGraphemes_Errors tests the validation of StringsGraphemes
+func TestStringsGraphemes_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		charset models.RuneSet
+		opts    GraphemeOptions
+	}{
+		{"zero count", 0, models.AlnumChars, GraphemeOptions{}},
+		{"negative marks", 5, models.AlnumChars, GraphemeOptions{MaxMarks: -1}},
+		{"only joiners", 5, models.NewRuneSet("\U0001F1E6\u200D\u0301"), GraphemeOptions{}},
+		{"empty charset", 5, models.RuneSet{}, GraphemeOptions{}},
+		{"non-mark marks", 5, models.AlnumChars, GraphemeOptions{MaxMarks: 1, Marks: models.NewRuneSet("a")}},
+		{"only linkers", 5, models.AlnumChars, GraphemeOptions{MaxMarks: 1, Marks: models.NewRuneSet("\u094D")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := StringsGraphemes(tt.count, tt.charset, tt.opts); err == nil {
+				t.Errorf("StringsGraphemes() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestRandomRunes tests that RandomRunes draws from non-ASCII charsets
+func TestRandomRunes(t *testing.T) {
+	for _, cs := range []models.RuneSet{models.GreekChars, models.CyrillicChars, models.CJKChars, models.EmojiChars} {
+		result, err := RandomRunes(32, cs)
+		if err != nil {
+			t.Fatalf("RandomRunes() error = %v", err)
+		}
+		if len(result) != 32 {
+			t.Errorf("RandomRunes() length = %d, want 32", len(result))
+		}
+		for _, r := range result {
+			if !cs.Contains(r) {
+				t.Errorf("RandomRunes() returned %U, not in charset", r)
+			}
+		}
+	}
+	if _, err := RandomRunes(5, models.RuneSet{}); err == nil {
+		t.Errorf("RandomRunes() with empty charset error = nil, want error")
+	}
+}
@@ -0,0 +1,255 @@
+package randutils
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// maxMaskLength is the largest number of characters a mask may generate.
+const maxMaskLength = 4096
+
+// maskLetters are the single-character placeholders of a mask.
+var maskLetters = map[rune]models.RuneSet{
+	'A': models.UpperChars,
+	'a': models.LowerChars,
+	'9': models.DigitChars,
+	'x': models.AlnumChars,
+}
+
+// maskSets are the named placeholders of a mask, used as {name} or {name:n}.
+var maskSets = map[string]models.RuneSet{
+	"upper":     models.UpperChars,
+	"lower":     models.LowerChars,
+	"digit":     models.DigitChars,
+	"alpha":     models.AlphabetChars,
+	"alnum":     models.AlnumChars,
+	"symbol":    models.SymbolChars,
+	"hex":       models.DigitChars.Union(models.NewRuneSet("abcdef")),
+	"crockford": models.CrockfordChars,
+	"readable":  models.NoLookalikeChars,
+}
+
+// MaskOptions configures CompileMask.
+type MaskOptions struct {
+	// Sets adds named character sets to the mask syntax, or replaces the
+	// built-in ones, so {name:n} draws n characters from Sets[name].
+	Sets map[string]models.RuneSet
+}
+
+// Mask generates random codes in a fixed format such as "AAA-999" or
+// "{upper:4}-{digit:6}". It is immutable and safe for concurrent use.
+type Mask struct {
+	mask     string
+	segments []maskSegment
+	length   int // number of characters in a generated code
+	size     int // upper bound on the length of a generated code in bytes
+}
+
+// maskSegment is a literal, or n characters drawn from set when n > 0.
+type maskSegment struct {
+	lit string
+	set models.RuneSet
+	n   int
+}
+
+// CompileMask parses a mask describing a code format. Characters of the
+// mask are placeholders replaced by a random character, or copied literally:
+//
+//	A          an uppercase letter (A-Z)
+//	a          a lowercase letter (a-z)
+//	9          a digit (0-9)
+//	x          an alphanumeric character (0-9, A-Z, a-z)
+//	{name}     a character from the named set
+//	{name:n}   n characters from the named set
+//	\c         the character c literally, e.g. \A or \{
+//
+// Any other character is a literal. The named sets are upper, lower, digit,
+// alpha, alnum, symbol, hex (0-9a-f), crockford (models.CrockfordChars) and
+// readable (models.NoLookalikeChars), plus those in opts.Sets.
+//
+// It returns an error if the mask is malformed, names an unknown or empty
+// set, has no placeholder or generates codes longer than 4096 characters.
+func CompileMask(mask string, opts MaskOptions) (*Mask, error) {
+	m := &Mask{mask: mask}
+	var lit strings.Builder
+	flush := func() {
+		if lit.Len() > 0 {
+			m.segments = append(m.segments, maskSegment{lit: lit.String()})
+			m.size += lit.Len()
+			lit.Reset()
+		}
+	}
+	runes := []rune(mask)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 == len(runes) {
+				return nil, fmt.Errorf("invalid mask: trailing backslash")
+			}
+			i++
+			if err := m.reserve(1); err != nil {
+				return nil, err
+			}
+			lit.WriteRune(runes[i])
+		case r == '{':
+			end := i + 1
+			for end < len(runes) && runes[end] != '}' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("invalid mask: unclosed brace at position %d", i)
+			}
+			set, n, err := parseMaskPlaceholder(string(runes[i+1:end]), opts)
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			if err := m.addPlaceholder(set, n); err != nil {
+				return nil, err
+			}
+			i = end
+		case maskLetters[r].Len() > 0:
+			flush()
+			if err := m.addPlaceholder(maskLetters[r], 1); err != nil {
+				return nil, err
+			}
+		default:
+			if err := m.reserve(1); err != nil {
+				return nil, err
+			}
+			lit.WriteRune(r)
+		}
+	}
+	flush()
+	if !slices.ContainsFunc(m.segments, func(seg maskSegment) bool { return seg.n > 0 }) {
+		return nil, fmt.Errorf("mask has no placeholders: %q", mask)
+	}
+	return m, nil
+}
+
+// parseMaskPlaceholder parses the inside of a {name} or {name:n} placeholder.
+func parseMaskPlaceholder(s string, opts MaskOptions) (models.RuneSet, int, error) {
+	name, count, hasCount := strings.Cut(s, ":")
+	n := 1
+	if hasCount {
+		var err error
+		if n, err = strconv.Atoi(count); err != nil || n <= 0 || n > maxMaskLength {
+			return models.RuneSet{}, 0, fmt.Errorf("invalid mask count: {%s}", s)
+		}
+	}
+	set, ok := opts.Sets[name]
+	if !ok {
+		set, ok = maskSets[name]
+	}
+	if !ok {
+		return models.RuneSet{}, 0, fmt.Errorf("unknown mask set: {%s}", s)
+	}
+	if set.Len() == 0 {
+		return models.RuneSet{}, 0, fmt.Errorf("mask set is empty: {%s}", s)
+	}
+	return set, n, nil
+}
+
+// addPlaceholder appends n characters from set, merging with a preceding
+// placeholder of the same set.
+func (m *Mask) addPlaceholder(set models.RuneSet, n int) error {
+	if err := m.reserve(n); err != nil {
+		return err
+	}
+	m.size += n * utf8MaxLen(set)
+	if last := len(m.segments) - 1; last >= 0 && m.segments[last].n > 0 && m.segments[last].set.Equal(set) {
+		m.segments[last].n += n
+		return nil
+	}
+	m.segments = append(m.segments, maskSegment{set: set, n: n})
+	return nil
+}
+
+// reserve adds n characters to the length of a generated code, or returns an
+// error if that would exceed maxMaskLength.
+func (m *Mask) reserve(n int) error {
+	if n > maxMaskLength-m.length {
+		return fmt.Errorf("mask is too long: more than %d characters", maxMaskLength)
+	}
+	m.length += n
+	return nil
+}
+
+// utf8MaxLen returns the UTF-8 length of the largest rune of the non-empty set.
+func utf8MaxLen(set models.RuneSet) int {
+	return len(string(set.At(set.Len() - 1)))
+}
+
+// String returns the mask m was compiled from.
+func (m *Mask) String() string {
+	return m.mask
+}
+
+// Keyspace returns the number of distinct codes m can generate.
+func (m *Mask) Keyspace() *big.Int {
+	keyspace := big.NewInt(1)
+	for _, seg := range m.segments {
+		if seg.n > 0 {
+			size := big.NewInt(int64(seg.set.Len()))
+			keyspace.Mul(keyspace, size.Exp(size, big.NewInt(int64(seg.n)), nil))
+		}
+	}
+	return keyspace
+}
+
+// Entropy returns the entropy of a generated code in bits, log2(Keyspace()).
+func (m *Mask) Entropy() float64 {
+	bits := 0.0
+	for _, seg := range m.segments {
+		if seg.n > 0 {
+			bits += float64(seg.n) * math.Log2(float64(seg.set.Len()))
+		}
+	}
+	return bits
+}
+
+// Generate returns a random code in the format of m. Every code is equally likely.
+func (m *Mask) Generate() (string, error) {
+	return m.GenerateWith(defaultGenerator)
+}
+
+// GenerateWith is like Generate but draws from g.
+func (m *Mask) GenerateWith(g *Generator) (string, error) {
+	var sb strings.Builder
+	sb.Grow(m.size)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, seg := range m.segments {
+		if seg.n == 0 {
+			sb.WriteString(seg.lit)
+			continue
+		}
+		for range seg.n {
+			r, err := g.runeLocked(seg.set)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String(), nil
+}
+
+// FromMask generates a random code in the format of mask, such as
+// "AAA-999" or "{upper:4}-{digit:6}"; see CompileMask for the syntax.
+// Compile the mask once with CompileMask to generate many codes.
+// It returns an error if the mask cannot be compiled.
+func (g *Generator) FromMask(mask string) (string, error) {
+	m, err := CompileMask(mask, MaskOptions{})
+	if err != nil {
+		return "", err
+	}
+	return m.GenerateWith(g)
+}
@@ -0,0 +1,194 @@
+package randutils
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// TestFromMask tests that generated codes follow their mask
+func TestFromMask(t *testing.T) {
+	tests := []struct {
+		mask string
+		want string
+	}{
+		{"AAA-999-xxx", `^[A-Z]{3}-[0-9]{3}-[0-9A-Za-z]{3}$`},
+		{"{upper:4}-{digit:6}", `^[A-Z]{4}-[0-9]{6}$`},
+		{"aa{lower}", `^[a-z]{3}$`},
+		{"{hex:8}", `^[0-9a-f]{8}$`},
+		{"{crockford:4}-{crockford:4}", `^[0-9A-HJKMNP-TV-Z]{4}-[0-9A-HJKMNP-TV-Z]{4}$`},
+		{"{readable:5}", `^[2-9A-HJ-NP-Za-km-np-z]{5}$`},
+		{"{alpha:2}{alnum:2}{symbol}", `^[A-Za-z]{2}[0-9A-Za-z]{2}[!-/:-@\[-` + "`" + `{-~]$`},
+		{`\A\a\9\x\{\\-9`, `^Aa9x\{\\-[0-9]$`},
+		{"ÄÖ-9", `^ÄÖ-[0-9]$`},
+	}
+
+	g := NewSeeded(testSeed())
+	for _, tt := range tests {
+		t.Run(tt.mask, func(t *testing.T) {
+			re := regexp.MustCompile(tt.want)
+			for range 200 {
+				code, err := g.FromMask(tt.mask)
+				if err != nil {
+					t.Fatalf("FromMask() error = %v", err)
+				}
+				if !re.MatchString(code) {
+					t.Fatalf("FromMask() = %q, want match for %s", code, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestCompileMask_Keyspace tests the keyspace and entropy reported for a mask
+func TestCompileMask_Keyspace(t *testing.T) {
+	tests := []struct {
+		mask string
+		want *big.Int
+	}{
+		{"AAA-999", big.NewInt(26 * 26 * 26 * 1000)},
+		{"{upper:4}-{digit:6}", big.NewInt(26 * 26 * 26 * 26 * 1000000)},
+		{"x", big.NewInt(62)},
+		{"{hex:40}", new(big.Int).Lsh(big.NewInt(1), 160)},
+		{"{crockford:2}", big.NewInt(32 * 32)},
+	}
+
+	for _, tt := range tests {
+		m, err := CompileMask(tt.mask, MaskOptions{})
+		if err != nil {
+			t.Fatalf("CompileMask(%q) error = %v", tt.mask, err)
+		}
+		if got := m.Keyspace(); got.Cmp(tt.want) != 0 {
+			t.Errorf("CompileMask(%q).Keyspace() = %v, want %v", tt.mask, got, tt.want)
+		}
+		want, _ := new(big.Float).SetInt(tt.want).Float64()
+		if got := m.Entropy(); math.Abs(got-math.Log2(want)) > 1e-9 {
+			t.Errorf("CompileMask(%q).Entropy() = %v, want %v", tt.mask, got, math.Log2(want))
+		}
+		if m.String() != tt.mask {
+			t.Errorf("String() = %q, want %q", m.String(), tt.mask)
+		}
+	}
+}
+
+// TestCompileMask_Uniform tests that every code of a small mask is equally likely
+func TestCompileMask_Uniform(t *testing.T) {
+	m, err := CompileMask("{bit}-{tri}{bit}", MaskOptions{Sets: map[string]models.RuneSet{
+		"bit": models.NewRuneSet("01"),
+		"tri": models.NewRuneSet("abc"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const cells = 12
+	samples := 1000 * cells
+	g := NewSeeded(testSeed())
+	counts := make(map[string]int)
+	for range samples {
+		code, err := m.GenerateWith(g)
+		if err != nil {
+			t.Fatalf("GenerateWith() error = %v", err)
+		}
+		counts[code]++
+	}
+	if len(counts) != cells {
+		t.Fatalf("GenerateWith() produced %d distinct codes, want %d", len(counts), cells)
+	}
+	if chi2, limit := chiSquareUniform(counts, cells, samples), chiSquareLimit(cells-1); chi2 > limit {
+		t.Errorf("chi-square = %.2f, want <= %.2f", chi2, limit)
+	}
+}
+
+// TestCompileMask_CustomSets tests that custom sets extend and override the built-in names
+func TestCompileMask_CustomSets(t *testing.T) {
+	m, err := CompileMask("{digit:3}{vowel:3}", MaskOptions{Sets: map[string]models.RuneSet{
+		"digit": models.NewRuneSet("7"),
+		"vowel": models.NewRuneSet("aeiou"),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := m.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^777[aeiou]{3}$`).MatchString(code) {
+		t.Errorf("Generate() = %q, want 777 and three vowels", code)
+	}
+	if m.Keyspace().Int64() != 125 {
+		t.Errorf("Keyspace() = %v, want 125", m.Keyspace())
+	}
+}
+
+// TestCompileMask_MaxLength tests that a mask may generate codes of up to maxMaskLength characters
+func TestCompileMask_MaxLength(t *testing.T) {
+	for _, mask := range []string{"{digit:4096}", "{digit:2048}{digit:2047}-", strings.Repeat("-", 4095) + "9"} {
+		m, err := CompileMask(mask, MaskOptions{})
+		if err != nil {
+			t.Fatalf("CompileMask(%.20q...) error = %v", mask, err)
+		}
+		code, err := m.GenerateWith(NewSeeded(testSeed()))
+		if err != nil {
+			t.Fatalf("GenerateWith() error = %v", err)
+		}
+		if len(code) != maxMaskLength {
+			t.Errorf("len(GenerateWith()) = %d, want %d", len(code), maxMaskLength)
+		}
+	}
+}
+
+// TestCompileMask_Errors tests that malformed masks are rejected
+func TestCompileMask_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		mask string
+		opts MaskOptions
+	}{
+		{"empty", "", MaskOptions{}},
+		{"literals only", "---", MaskOptions{}},
+		{"escaped only", `\A\9`, MaskOptions{}},
+		{"trailing backslash", `AAA\`, MaskOptions{}},
+		{"unclosed brace", "{upper:4", MaskOptions{}},
+		{"unknown set", "{vowel:4}", MaskOptions{}},
+		{"zero count", "{digit:0}", MaskOptions{}},
+		{"negative count", "{digit:-1}", MaskOptions{}},
+		{"bad count", "{digit:four}", MaskOptions{}},
+		{"oversized count", "{digit:4097}", MaskOptions{}},
+		{"huge count", "{digit:9223372036854775807}", MaskOptions{}},
+		{"overflowing counts", "{digit:4611686018427387904}{digit:4611686018427387904}", MaskOptions{}},
+		{"too long", "{digit:4096}9", MaskOptions{}},
+		{"too long merged", "{digit:2048}{digit:2049}", MaskOptions{}},
+		{"too long literals", "9" + strings.Repeat("-", 4096), MaskOptions{}},
+		{"empty set", "{none:2}", MaskOptions{Sets: map[string]models.RuneSet{"none": {}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CompileMask(tt.mask, tt.opts); err == nil {
+				t.Errorf("CompileMask(%q) error = nil, want error", tt.mask)
+			}
+			if tt.opts.Sets == nil {
+				if _, err := FromMask(tt.mask); err == nil {
+					t.Errorf("FromMask(%q) error = nil, want error", tt.mask)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMask measures generation from a compiled mask
+func BenchmarkMask(b *testing.B) {
+	m, err := CompileMask("{upper:4}-{digit:6}-xxxx", MaskOptions{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for range b.N {
+		if _, err := m.Generate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
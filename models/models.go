@@ -15,6 +15,31 @@ const (
 	symbol1End   = 47  // '/'
 	symbol2Start = 58  // ':'
 	symbol2End   = 64  // '@'
+	symbol3Start = 91  // '['
+	symbol3End   = 96  // '`'
+	symbol4Start = 123 // '{'
+	symbol4End   = 126 // '~'
+	printStart   = 33  // '!'
+	printEnd     = 126 // '~'
+)
+
+// Symbol groups, as strings of ASCII characters
+const (
+	punctuationChars = "!\"',.:;?"
+	bracketChars     = "()<>[]{}"
+	// shellSafeChars need no quoting in POSIX shells (as in Python's shlex.quote)
+	shellSafeChars = "%+,-./:=@_"
+	// urlSafeChars are the unreserved symbols of RFC 3986
+	urlSafeChars = "-._~"
+)
+
+// Characters left out of the readable alphabets
+const (
+	// crockfordExcluded are the letters Crockford's Base32 drops: I and L
+	// read as 1, O as 0, and U avoids accidental obscenity
+	crockfordExcluded = "ILOU"
+	// lookalikes are alphanumerics confused with one another (0/O/o, 1/l/I)
+	lookalikes = "0Oo1lI"
 )
 
 var (
@@ -24,8 +49,19 @@ var (
 	Lowerset = make([]int, lowerEnd-lowerStart+1)
 	// Upperset contains ASCII codes for uppercase letters A-Z
 	Upperset = make([]int, upperEnd-upperStart+1)
-	// Symbolset contains ASCII codes for special symbols (!-/ and :-@)
-	Symbolset = make([]int, (symbol1End-symbol1Start+1)+(symbol2End-symbol2Start+1))
+	// Symbolset contains ASCII codes for all 32 printable symbols (!-/, :-@, [-` and {-~)
+	Symbolset = make([]int, 0, (symbol1End-symbol1Start+1)+(symbol2End-symbol2Start+1)+
+		(symbol3End-symbol3Start+1)+(symbol4End-symbol4Start+1))
+	// Punctuationset contains ASCII codes for sentence punctuation (!"',.:;?)
+	Punctuationset = codes(punctuationChars)
+	// Bracketset contains ASCII codes for brackets and braces (()<>[]{})
+	Bracketset = codes(bracketChars)
+	// ShellSafeSymbolset contains ASCII codes for symbols that need no shell quoting (%+,-./:=@_)
+	ShellSafeSymbolset = codes(shellSafeChars)
+	// URLSafeSymbolset contains ASCII codes for the URL-safe unreserved symbols (-._~)
+	URLSafeSymbolset = codes(urlSafeChars)
+	// Printableset contains ASCII codes for every printable character except space (33-126)
+	Printableset = make([]int, printEnd-printStart+1)
 
 	// Alphabetset contains ASCII codes for all letters (A-Z, a-z)
 	Alphabetset = make([]int, len(Lowerset)+len(Upperset))
@@ -48,13 +84,20 @@ func init() {
 	for i := range upperEnd - upperStart + 1 {
 		Upperset[i] = upperStart + i
 	}
-	// Initialize first symbol range ASCII codes (33-47)
-	for i := range symbol1End - symbol1Start + 1 {
-		Symbolset[i] = symbol1Start + i
+	// Initialize symbol ASCII codes (33-47, 58-64, 91-96, 123-126)
+	for _, r := range [][2]int{
+		{symbol1Start, symbol1End},
+		{symbol2Start, symbol2End},
+		{symbol3Start, symbol3End},
+		{symbol4Start, symbol4End},
+	} {
+		for c := r[0]; c <= r[1]; c++ {
+			Symbolset = append(Symbolset, c)
+		}
 	}
-	// Initialize second symbol range ASCII codes (58-64)
-	for i := range symbol2End - symbol2Start + 1 {
-		Symbolset[(symbol1End-symbol1Start+1)+i] = symbol2Start + i
+	// Initialize printable ASCII codes (33-126)
+	for i := range printEnd - printStart + 1 {
+		Printableset[i] = printStart + i
 	}
 
 	// Combine character sets using slices.Concat
@@ -62,3 +105,12 @@ func init() {
 	Charset = slices.Concat(Numset, Upperset, Lowerset)
 	Allset = slices.Concat(Numset, Upperset, Lowerset, Symbolset)
 }
+
+// codes returns the ASCII codes of the characters in s.
+func codes(s string) []int {
+	result := make([]int, len(s))
+	for i := range len(s) {
+		result[i] = int(s[i])
+	}
+	return result
+}
@@ -58,25 +58,75 @@ func TestUpperset(t *testing.T) {
 
 // TestSymbolset verifies the Symbolset contains correct ASCII codes for special symbols
 func TestSymbolset(t *testing.T) {
-	// 33-47 (15 chars) + 58-64 (7 chars) = 22 chars
-	expectedLen := 15 + 7
-	if len(Symbolset) != expectedLen {
-		t.Errorf("Symbolset length = %d, want %d", len(Symbolset), expectedLen)
+	// 33-47 (15 chars) + 58-64 (7 chars) + 91-96 (6 chars) + 123-126 (4 chars) = 32 chars
+	expected := []int{}
+	for c := 33; c <= 126; c++ {
+		if !(c >= 48 && c <= 57) && !(c >= 65 && c <= 90) && !(c >= 97 && c <= 122) {
+			expected = append(expected, c)
+		}
 	}
-
-	// Check first range (33-47)
-	for i := 0; i < 15; i++ {
-		if Symbolset[i] != 33+i {
-			t.Errorf("Symbolset[%d] = %d, want %d", i, Symbolset[i], 33+i)
+	if len(expected) != 32 {
+		t.Fatalf("expected symbol count = %d, want 32", len(expected))
+	}
+	if !slices.Equal(Symbolset, expected) {
+		t.Errorf("Symbolset = %v, want %v", Symbolset, expected)
+	}
+	for _, c := range "[\\]^_`{|}~" {
+		if !slices.Contains(Symbolset, int(c)) {
+			t.Errorf("Symbolset is missing %q", c)
 		}
 	}
+}
+
+// TestSymbolGroups verifies the named symbol groups are subsets of Symbolset
+func TestSymbolGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		set  []int
+		want string
+	}{
+		{"Punctuationset", Punctuationset, `!"',.:;?`},
+		{"Bracketset", Bracketset, "()<>[]{}"},
+		{"ShellSafeSymbolset", ShellSafeSymbolset, "%+,-./:=@_"},
+		{"URLSafeSymbolset", URLSafeSymbolset, "-._~"},
+	}
 
-	// Check second range (58-64)
-	for i := 0; i < 7; i++ {
-		if Symbolset[15+i] != 58+i {
-			t.Errorf("Symbolset[%d] = %d, want %d", 15+i, Symbolset[15+i], 58+i)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(toRunes(tt.set)); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+			for _, c := range tt.set {
+				if !slices.Contains(Symbolset, c) {
+					t.Errorf("%s contains %q, not in Symbolset", tt.name, rune(c))
+				}
+			}
+		})
+	}
+}
+
+// TestPrintableset verifies the Printableset contains every printable ASCII character except space
+func TestPrintableset(t *testing.T) {
+	if len(Printableset) != 94 {
+		t.Errorf("Printableset length = %d, want 94", len(Printableset))
+	}
+	for i, c := range Printableset {
+		if c != 33+i {
+			t.Errorf("Printableset[%d] = %d, want %d", i, c, 33+i)
 		}
 	}
+	if !slices.Equal(Printableset, slices.Sorted(slices.Values(Allset))) {
+		t.Errorf("Printableset and Allset contain different characters")
+	}
+}
+
+// toRunes converts ASCII codes to runes.
+func toRunes(set []int) []rune {
+	runes := make([]rune, len(set))
+	for i, c := range set {
+		runes[i] = rune(c)
+	}
+	return runes
 }
 
 // TestAlphabetset verifies the Alphabetset contains all letters
@@ -184,13 +234,14 @@ func TestInitialization(t *testing.T) {
 	// This test ensures that the init() function runs correctly by checking
 	// that all sets are non-nil and have the correct sizes
 	sets := map[string][]int{
-		"Numset":      Numset,
-		"Lowerset":    Lowerset,
-		"Upperset":    Upperset,
-		"Symbolset":   Symbolset,
-		"Alphabetset": Alphabetset,
-		"Charset":     Charset,
-		"Allset":      Allset,
+		"Numset":       Numset,
+		"Lowerset":     Lowerset,
+		"Upperset":     Upperset,
+		"Symbolset":    Symbolset,
+		"Alphabetset":  Alphabetset,
+		"Charset":      Charset,
+		"Allset":       Allset,
+		"Printableset": Printableset,
 	}
 
 	for name, set := range sets {
@@ -0,0 +1,289 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneSet is an immutable set of Unicode code points used for random generation.
+// Unlike the []int sets, a RuneSet never contains duplicates, cannot be
+// modified once built, and supports set algebra, so derived sets such as
+// "alphanumerics minus look-alikes" can be built safely from shared values.
+//
+// Internally a RuneSet is a sorted list of disjoint rune ranges with
+// cumulative counts, so large Unicode blocks cost a few words of memory and
+// At runs in logarithmic time. Surrogate code points (U+D800-U+DFFF) cannot
+// be encoded in UTF-8 and are never members. The zero value is the empty set.
+type RuneSet struct {
+	ranges []runeRange
+	cum    []int // cum[i] is the number of runes in ranges[:i+1]
+}
+
+// runeRange is the inclusive interval [lo, hi].
+type runeRange struct {
+	lo, hi rune
+}
+
+// NewRuneSet returns the set of distinct characters in chars.
+// Invalid UTF-8 bytes are decoded as utf8.RuneError (U+FFFD).
+func NewRuneSet(chars string) RuneSet {
+	ranges := make([]runeRange, 0, len(chars))
+	for _, r := range chars {
+		ranges = append(ranges, runeRange{r, r})
+	}
+	return normalize(ranges)
+}
+
+// NewRuneSetRange returns the set of code points in [lo, hi], excluding surrogates.
+// It returns an error if lo > hi or either bound is not a valid code point.
+func NewRuneSetRange(lo, hi rune) (RuneSet, error) {
+	if lo < 0 || hi > unicode.MaxRune || lo > hi {
+		return RuneSet{}, fmt.Errorf("invalid rune range: %U-%U", lo, hi)
+	}
+	return normalize([]runeRange{{lo, hi}}), nil
+}
+
+// NewRuneSetInts returns the set of distinct code points in codes, such as
+// one of the []int sets of this package. It returns an error if a code is
+// negative, above unicode.MaxRune or a surrogate.
+func NewRuneSetInts(codes []int) (RuneSet, error) {
+	ranges := make([]runeRange, 0, len(codes))
+	for _, c := range codes {
+		if c < 0 || c > unicode.MaxRune || !utf8.ValidRune(rune(c)) {
+			return RuneSet{}, fmt.Errorf("invalid code point: %d", c)
+		}
+		ranges = append(ranges, runeRange{rune(c), rune(c)})
+	}
+	return normalize(ranges), nil
+}
+
+// NewRuneSetTable returns the set of code points in table, such as
+// unicode.Greek or unicode.Sm.
+func NewRuneSetTable(table *unicode.RangeTable) RuneSet {
+	var ranges []runeRange
+	for _, r := range table.R16 {
+		ranges = appendStride(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	for _, r := range table.R32 {
+		ranges = appendStride(ranges, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+	}
+	return normalize(ranges)
+}
+
+// appendStride appends the code points lo, lo+stride, ..., hi to ranges.
+func appendStride(ranges []runeRange, lo, hi, stride rune) []runeRange {
+	if stride == 1 {
+		return append(ranges, runeRange{lo, hi})
+	}
+	for r := lo; r <= hi; r += stride {
+		ranges = append(ranges, runeRange{r, r})
+	}
+	return ranges
+}
+
+// charsetOf builds a predefined RuneSet from inclusive rune pairs.
+func charsetOf(pairs ...rune) RuneSet {
+	ranges := make([]runeRange, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ranges = append(ranges, runeRange{pairs[i], pairs[i+1]})
+	}
+	return normalize(ranges)
+}
+
+// normalize sorts and merges ranges, removes surrogates and computes the
+// cumulative counts. It may reorder ranges in place.
+func normalize(ranges []runeRange) RuneSet {
+	slices.SortFunc(ranges, func(a, b runeRange) int { return cmp.Compare(a.lo, b.lo) })
+	merged := make([]runeRange, 0, len(ranges))
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.lo <= merged[n-1].hi+1 {
+			merged[n-1].hi = max(merged[n-1].hi, r.hi)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return build(subtract(merged, []runeRange{{surrogateMin, surrogateMax}}))
+}
+
+// Surrogate code points reserved for UTF-16.
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+// build computes the cumulative counts of sorted, disjoint, non-adjacent ranges.
+func build(ranges []runeRange) RuneSet {
+	if len(ranges) == 0 {
+		return RuneSet{}
+	}
+	cum := make([]int, len(ranges))
+	total := 0
+	for i, r := range ranges {
+		total += int(r.hi-r.lo) + 1
+		cum[i] = total
+	}
+	return RuneSet{ranges: slices.Clip(ranges), cum: cum}
+}
+
+// Len returns the number of code points in c.
+func (c RuneSet) Len() int {
+	if len(c.cum) == 0 {
+		return 0
+	}
+	return c.cum[len(c.cum)-1]
+}
+
+// Contains reports whether r is a member of c.
+func (c RuneSet) Contains(r rune) bool {
+	i := sort.Search(len(c.ranges), func(i int) bool { return c.ranges[i].hi >= r })
+	return i < len(c.ranges) && c.ranges[i].lo <= r
+}
+
+// At returns the i-th code point of c in ascending order.
+// It panics if i is not in [0, c.Len()).
+func (c RuneSet) At(i int) rune {
+	if i < 0 || i >= c.Len() {
+		panic(fmt.Sprintf("models: RuneSet index %d out of range [0, %d)", i, c.Len()))
+	}
+	j := sort.Search(len(c.cum), func(j int) bool { return c.cum[j] > i })
+	start := 0
+	if j > 0 {
+		start = c.cum[j-1]
+	}
+	return c.ranges[j].lo + rune(i-start)
+}
+
+// Union returns the set of code points in c or other.
+func (c RuneSet) Union(other RuneSet) RuneSet {
+	return normalize(slices.Concat(c.ranges, other.ranges))
+}
+
+// Intersect returns the set of code points in both c and other.
+func (c RuneSet) Intersect(other RuneSet) RuneSet {
+	var result []runeRange
+	a, b := c.ranges, other.ranges
+	for len(a) > 0 && len(b) > 0 {
+		lo, hi := max(a[0].lo, b[0].lo), min(a[0].hi, b[0].hi)
+		if lo <= hi {
+			result = append(result, runeRange{lo, hi})
+		}
+		if a[0].hi < b[0].hi {
+			a = a[1:]
+		} else {
+			b = b[1:]
+		}
+	}
+	return build(result)
+}
+
+// Subtract returns the set of code points in c but not in other.
+func (c RuneSet) Subtract(other RuneSet) RuneSet {
+	return build(subtract(c.ranges, other.ranges))
+}
+
+// subtract returns the ranges of a not covered by b.
+// Both inputs must be sorted and disjoint; neither is modified.
+func subtract(a, b []runeRange) []runeRange {
+	var result []runeRange
+	for _, r := range a {
+		for len(b) > 0 && b[0].hi < r.lo {
+			b = b[1:]
+		}
+		lo := r.lo
+		for _, s := range b {
+			if s.lo > r.hi {
+				break
+			}
+			if s.lo > lo {
+				result = append(result, runeRange{lo, s.lo - 1})
+			}
+			lo = max(lo, s.hi+1)
+		}
+		if lo <= r.hi {
+			result = append(result, runeRange{lo, r.hi})
+		}
+	}
+	return result
+}
+
+// Equal reports whether c and other contain the same code points.
+func (c RuneSet) Equal(other RuneSet) bool {
+	return slices.Equal(c.ranges, other.ranges)
+}
+
+// Runes returns the members of c in ascending order.
+func (c RuneSet) Runes() []rune {
+	runes := make([]rune, 0, c.Len())
+	for _, r := range c.ranges {
+		for x := r.lo; x <= r.hi; x++ {
+			runes = append(runes, x)
+		}
+	}
+	return runes
+}
+
+// Ints returns the members of c in ascending order as a []int,
+// the form accepted by randutils.Random.
+func (c RuneSet) Ints() []int {
+	ints := make([]int, 0, c.Len())
+	for _, r := range c.ranges {
+		for x := r.lo; x <= r.hi; x++ {
+			ints = append(ints, int(x))
+		}
+	}
+	return ints
+}
+
+// String returns the members of c in ascending order as a UTF-8 string.
+func (c RuneSet) String() string {
+	var sb strings.Builder
+	for _, r := range c.ranges {
+		for x := r.lo; x <= r.hi; x++ {
+			sb.WriteRune(x)
+		}
+	}
+	return sb.String()
+}
+
+var (
+	// DigitChars contains the digits 0-9
+	DigitChars = charsetOf(numStart, numEnd)
+	// LowerChars contains the lowercase letters a-z
+	LowerChars = charsetOf(lowerStart, lowerEnd)
+	// UpperChars contains the uppercase letters A-Z
+	UpperChars = charsetOf(upperStart, upperEnd)
+	// SymbolChars contains the 32 printable ASCII symbols of Symbolset
+	SymbolChars = charsetOf(symbol1Start, symbol1End, symbol2Start, symbol2End,
+		symbol3Start, symbol3End, symbol4Start, symbol4End)
+	// PunctuationChars contains sentence punctuation (!"',.:;?)
+	PunctuationChars = NewRuneSet(punctuationChars)
+	// BracketChars contains brackets and braces (()<>[]{})
+	BracketChars = NewRuneSet(bracketChars)
+	// ShellSafeChars contains the symbols that need no shell quoting (%+,-./:=@_)
+	ShellSafeChars = NewRuneSet(shellSafeChars)
+	// URLSafeChars contains the URL-safe unreserved symbols (-._~)
+	URLSafeChars = NewRuneSet(urlSafeChars)
+	// PrintableChars contains every printable ASCII character except space
+	PrintableChars = charsetOf(printStart, printEnd)
+
+	// CrockfordChars contains Crockford's Base32 alphabet: digits and
+	// uppercase letters without I, L, O and U (0-9, A-Z minus ILOU)
+	CrockfordChars = DigitChars.Union(UpperChars).Subtract(NewRuneSet(crockfordExcluded))
+	// NoLookalikeChars contains the alphanumeric characters without those
+	// easily confused when read aloud or in common fonts (0, O, o, 1, l, I)
+	NoLookalikeChars = AlnumChars.Subtract(NewRuneSet(lookalikes))
+	// LowerDigitChars contains the lowercase letters and digits (0-9, a-z)
+	LowerDigitChars = DigitChars.Union(LowerChars)
+
+	// AlphabetChars contains all letters (A-Z, a-z)
+	AlphabetChars = UpperChars.Union(LowerChars)
+	// AlnumChars contains the alphanumeric characters (0-9, A-Z, a-z)
+	AlnumChars = DigitChars.Union(AlphabetChars)
+	// AllChars contains digits, letters and special symbols, matching Allset
+	AllChars = AlnumChars.Union(SymbolChars)
+)
@@ -0,0 +1,212 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"unicode"
+)
+
+// TestNewRuneSet tests that NewRuneSet deduplicates and sorts its input
+func TestNewRuneSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"sorted", "abc", "abc"},
+		{"unsorted with duplicates", "cabbac", "abc"},
+		{"adjacent ranges merge", "0123456789", "0123456789"},
+		{"non-ASCII", "ωαβα", "αβω"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := NewRuneSet(tt.input)
+			if got := cs.String(); got != tt.want {
+				t.Errorf("NewRuneSet(%q).String() = %q, want %q", tt.input, got, tt.want)
+			}
+			if got := cs.Len(); got != len([]rune(tt.want)) {
+				t.Errorf("NewRuneSet(%q).Len() = %d, want %d", tt.input, got, len([]rune(tt.want)))
+			}
+		})
+	}
+}
+
+// TestNewRuneSetRange tests range construction and validation
+func TestNewRuneSetRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		lo, hi  rune
+		wantLen int
+		wantErr bool
+	}{
+		{"digits", '0', '9', 10, false},
+		{"single", 'x', 'x', 1, false},
+		{"surrogates excluded", 0xD7FF, 0xE000, 2, false},
+		{"whole surrogate block", 0xD800, 0xDFFF, 0, false},
+		{"full range", 0, unicode.MaxRune, unicode.MaxRune + 1 - 2048, false},
+		{"reversed", 'z', 'a', 0, true},
+		{"negative", -1, 'a', 0, true},
+		{"above max rune", 'a', unicode.MaxRune + 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cs, err := NewRuneSetRange(tt.lo, tt.hi)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewRuneSetRange(%U, %U) error = %v, wantErr %v", tt.lo, tt.hi, err, tt.wantErr)
+			}
+			if cs.Len() != tt.wantLen {
+				t.Errorf("NewRuneSetRange(%U, %U).Len() = %d, want %d", tt.lo, tt.hi, cs.Len(), tt.wantLen)
+			}
+			if cs.Contains(0xD800) {
+				t.Errorf("NewRuneSetRange(%U, %U) contains a surrogate", tt.lo, tt.hi)
+			}
+		})
+	}
+}
+
+// TestNewRuneSetInts tests conversion from the []int sets
+func TestNewRuneSetInts(t *testing.T) {
+	cs, err := NewRuneSetInts(Allset)
+	if err != nil {
+		t.Fatalf("NewRuneSetInts(Allset) error = %v", err)
+	}
+	if !cs.Equal(AllChars) {
+		t.Errorf("NewRuneSetInts(Allset) = %q, want %q", cs, AllChars)
+	}
+	for _, codes := range [][]int{{-1}, {0xD800}, {unicode.MaxRune + 1}, {1<<32 + 'a'}} {
+		if _, err := NewRuneSetInts(codes); err == nil {
+			t.Errorf("NewRuneSetInts(%v) error = nil, want error", codes)
+		}
+	}
+}
+
+// TestRuneSet_SetAlgebra tests Union, Intersect and Subtract
+func TestRuneSet_SetAlgebra(t *testing.T) {
+	abc := NewRuneSet("abcdef")
+	def := NewRuneSet("defxyz")
+	tests := []struct {
+		name string
+		got  RuneSet
+		want string
+	}{
+		{"union", abc.Union(def), "abcdefxyz"},
+		{"intersect", abc.Intersect(def), "def"},
+		{"subtract", abc.Subtract(def), "abc"},
+		{"subtract middle", AlnumChars.Subtract(NewRuneSet("0O1lI")), "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"},
+		{"intersect disjoint", DigitChars.Intersect(LowerChars), ""},
+		{"subtract everything", abc.Subtract(AlphabetChars), ""},
+		{"union with empty", abc.Union(RuneSet{}), "abcdef"},
+		{"intersect with empty", abc.Intersect(RuneSet{}), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+			if !tt.got.Equal(NewRuneSet(tt.want)) {
+				t.Errorf("%s is not normalized: %q", tt.name, tt.got)
+			}
+		})
+	}
+
+	// The operands must be left untouched.
+	if abc.String() != "abcdef" || def.String() != "defxyz" {
+		t.Errorf("operands modified: %q, %q", abc, def)
+	}
+}
+
+// TestRuneSet_Contains tests membership at and around range boundaries
+func TestRuneSet_Contains(t *testing.T) {
+	for _, r := range "09AZaz" {
+		if !AlnumChars.Contains(r) {
+			t.Errorf("AlnumChars.Contains(%q) = false, want true", r)
+		}
+	}
+	for _, r := range "/:@[`{ é" {
+		if AlnumChars.Contains(r) {
+			t.Errorf("AlnumChars.Contains(%q) = true, want false", r)
+		}
+	}
+	if (RuneSet{}).Contains('a') {
+		t.Errorf("empty RuneSet contains 'a'")
+	}
+}
+
+// TestRuneSet_At tests that At enumerates the members in order
+func TestRuneSet_At(t *testing.T) {
+	runes := AllChars.Runes()
+	if len(runes) != AllChars.Len() {
+		t.Fatalf("Runes() length = %d, want %d", len(runes), AllChars.Len())
+	}
+	for i, r := range runes {
+		if got := AllChars.At(i); got != r {
+			t.Errorf("At(%d) = %q, want %q", i, got, r)
+		}
+	}
+	for _, i := range []int{-1, AllChars.Len()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("At(%d) did not panic", i)
+				}
+			}()
+			AllChars.At(i)
+		}()
+	}
+}
+
+// TestRuneSet_PredefinedMatchSets tests that the predefined RuneSets match the []int sets
+func TestRuneSet_PredefinedMatchSets(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset RuneSet
+		set     []int
+	}{
+		{"DigitChars", DigitChars, Numset},
+		{"LowerChars", LowerChars, Lowerset},
+		{"UpperChars", UpperChars, Upperset},
+		{"SymbolChars", SymbolChars, Symbolset},
+		{"AlphabetChars", AlphabetChars, Alphabetset},
+		{"AlnumChars", AlnumChars, Charset},
+		{"AllChars", AllChars, Allset},
+		{"PunctuationChars", PunctuationChars, Punctuationset},
+		{"BracketChars", BracketChars, Bracketset},
+		{"ShellSafeChars", ShellSafeChars, ShellSafeSymbolset},
+		{"URLSafeChars", URLSafeChars, URLSafeSymbolset},
+		{"PrintableChars", PrintableChars, Printableset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Sorted(slices.Values(tt.set))
+			if got := tt.charset.Ints(); !slices.Equal(got, want) {
+				t.Errorf("%s.Ints() = %v, want %v", tt.name, got, want)
+			}
+		})
+	}
+}
+
+// TestReadableCharsets tests the predefined ambiguity-free charsets
+func TestReadableCharsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset RuneSet
+		want    string
+	}{
+		{"CrockfordChars", CrockfordChars, "0123456789ABCDEFGHJKMNPQRSTVWXYZ"},
+		{"NoLookalikeChars", NoLookalikeChars, "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz"},
+		{"LowerDigitChars", LowerDigitChars, "0123456789abcdefghijklmnopqrstuvwxyz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.charset.String(); got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
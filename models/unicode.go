@@ -0,0 +1,42 @@
+package models
+
+import "unicode"
+
+// Unicode blocks used by the predefined non-ASCII charsets
+const (
+	greekStart    = 0x0370 // Greek and Coptic
+	greekEnd      = 0x03FF
+	cyrillicStart = 0x0400 // Cyrillic
+	cyrillicEnd   = 0x04FF
+	cjkStart      = 0x4E00 // CJK Unified Ideographs
+	cjkEnd        = 0x9FFF
+	combiningLo   = 0x0300 // Combining Diacritical Marks
+	combiningHi   = 0x036F
+)
+
+// Emoji blocks: Miscellaneous Symbols and Pictographs, Emoticons, Transport
+// and Map Symbols, and Supplemental Symbols and Pictographs
+var emojiBlocks = charsetOf(
+	0x1F300, 0x1F5FF,
+	0x1F600, 0x1F64F,
+	0x1F680, 0x1F6FF,
+	0x1F900, 0x1F9FF,
+)
+
+var (
+	// GreekChars contains the Greek letters of the Greek and Coptic block (U+0370-U+03FF)
+	GreekChars = charsetOf(greekStart, greekEnd).
+			Intersect(NewRuneSetTable(unicode.Greek)).
+			Intersect(NewRuneSetTable(unicode.L))
+	// CyrillicChars contains the letters of the Cyrillic block (U+0400-U+04FF)
+	CyrillicChars = charsetOf(cyrillicStart, cyrillicEnd).
+			Intersect(NewRuneSetTable(unicode.L))
+	// CJKChars contains the CJK Unified Ideographs block (U+4E00-U+9FFF)
+	CJKChars = charsetOf(cjkStart, cjkEnd).
+			Intersect(NewRuneSetTable(unicode.Han))
+	// EmojiChars contains the pictographic symbols (category So) of the main emoji blocks.
+	// Each is a single code point; modifier, flag and ZWJ sequences are not included.
+	EmojiChars = emojiBlocks.Intersect(NewRuneSetTable(unicode.So))
+	// CombiningChars contains the Combining Diacritical Marks block (U+0300-U+036F)
+	CombiningChars = charsetOf(combiningLo, combiningHi)
+)
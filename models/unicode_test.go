@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"unicode"
+)
+
+// TestNewRuneSetTable tests conversion of unicode range tables, including strided ranges
+func TestNewRuneSetTable(t *testing.T) {
+	table := &unicode.RangeTable{
+		R16: []unicode.Range16{{Lo: 'a', Hi: 'e', Stride: 2}, {Lo: 'x', Hi: 'z', Stride: 1}},
+		R32: []unicode.Range32{{Lo: 0x1F600, Hi: 0x1F601, Stride: 1}},
+	}
+	if got, want := NewRuneSetTable(table).String(), "acexyz\U0001F600\U0001F601"; got != want {
+		t.Errorf("NewRuneSetTable() = %q, want %q", got, want)
+	}
+	if !NewRuneSetTable(unicode.Nd).Intersect(DigitChars).Equal(DigitChars) {
+		t.Errorf("NewRuneSetTable(unicode.Nd) does not contain 0-9")
+	}
+}
+
+// TestUnicodeCharsets tests the predefined Unicode block charsets
+func TestUnicodeCharsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset RuneSet
+		lo, hi  rune
+		is      func(rune) bool
+		minLen  int
+	}{
+		{"GreekChars", GreekChars, 0x0370, 0x03FF, func(r rune) bool { return unicode.Is(unicode.Greek, r) && unicode.IsLetter(r) }, 100},
+		{"CyrillicChars", CyrillicChars, 0x0400, 0x04FF, unicode.IsLetter, 200},
+		{"CJKChars", CJKChars, 0x4E00, 0x9FFF, func(r rune) bool { return unicode.Is(unicode.Han, r) }, 20000},
+		{"EmojiChars", EmojiChars, 0x1F300, 0x1F9FF, func(r rune) bool { return unicode.Is(unicode.So, r) }, 1000},
+		{"CombiningChars", CombiningChars, 0x0300, 0x036F, unicode.IsMark, 112},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.charset.Len() < tt.minLen {
+				t.Errorf("%s.Len() = %d, want at least %d", tt.name, tt.charset.Len(), tt.minLen)
+			}
+			for _, r := range tt.charset.Runes() {
+				if r < tt.lo || r > tt.hi || !tt.is(r) {
+					t.Errorf("%s contains unexpected %U", tt.name, r)
+				}
+			}
+		})
+	}
+	for _, r := range "αΩ" {
+		if !GreekChars.Contains(r) {
+			t.Errorf("GreekChars does not contain %q", r)
+		}
+	}
+	if !CyrillicChars.Contains('Ж') || !CJKChars.Contains('中') || !EmojiChars.Contains('😀') {
+		t.Errorf("predefined charsets are missing common characters")
+	}
+}
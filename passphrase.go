@@ -0,0 +1,360 @@
+package randutils
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// The EFF wordlists are published by the Electronic Frontier Foundation under
+// CC BY 3.0 US; see https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases.
+var (
+	//go:embed wordlists/eff_large_wordlist.txt
+	effLarge []byte
+	//go:embed wordlists/eff_short_wordlist_2_0.txt
+	effShort []byte
+)
+
+// Wordlist is an immutable list of distinct words for Passphrase.
+type Wordlist struct {
+	words []string
+	// clash is a word whose capitalized form is also another word's, and
+	// fixed a word whose capitalized form is itself or in the list; either
+	// is empty if there is none.
+	clash, fixed string
+	// ambiguous reports, for each Capitalization, a word that can be part
+	// of two different word sequences joined to the same string, or ""
+	// if concatenation is unambiguous.
+	ambiguous [CapitalizeRandom + 1]func() string
+}
+
+var (
+	effLargeWordlist = sync.OnceValue(func() *Wordlist { return mustWordlist(effLarge) })
+	effShortWordlist = sync.OnceValue(func() *Wordlist { return mustWordlist(effShort) })
+)
+
+// EFFLargeWordlist returns the EFF large wordlist of 7776 words, the default
+// for Passphrase. Each word carries about 12.9 bits of entropy.
+func EFFLargeWordlist() *Wordlist {
+	return effLargeWordlist()
+}
+
+// EFFShortWordlist returns the EFF short wordlist 2.0 of 1296 longer, more
+// memorable words whose first three letters are unique, so they can be
+// typed with autocomplete. Each word carries about 10.3 bits of entropy.
+func EFFShortWordlist() *Wordlist {
+	return effShortWordlist()
+}
+
+// mustWordlist parses an embedded wordlist.
+func mustWordlist(data []byte) *Wordlist {
+	wl, err := LoadWordlist(bytes.NewReader(data))
+	if err != nil {
+		panic(err)
+	}
+	return wl
+}
+
+// LoadWordlist reads a wordlist with one word per line. Lines in diceware
+// format, where the word follows a number of dice rolls ("11111\tabacus"),
+// are accepted too. Blank lines and lines starting with '#' are ignored.
+// It returns an error if a line holds more than one word, a word repeats,
+// fewer than two words are found, or reading r fails.
+func LoadWordlist(r io.Reader) (*Wordlist, error) {
+	scanner := bufio.NewScanner(r)
+	seen := make(map[string]bool)
+	var words []string
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) == 2 && strings.Trim(fields[0], "0123456789") == "" {
+			fields = fields[1:]
+		}
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("invalid wordlist line %d: %q", line, scanner.Text())
+		}
+		word := fields[0]
+		if seen[word] {
+			return nil, fmt.Errorf("duplicate word on line %d: %q", line, word)
+		}
+		seen[word] = true
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read wordlist: %w", err)
+	}
+	if len(words) < 2 {
+		return nil, fmt.Errorf("wordlist has %d words, need at least 2", len(words))
+	}
+	wl := &Wordlist{words: words}
+	capitals := make(map[string]bool, len(words))
+	for _, word := range words {
+		capital := capitalizeFirst(word)
+		if capitals[capital] && wl.clash == "" {
+			wl.clash = word
+		}
+		if (capital == word || seen[capital] || capitals[capital]) && wl.fixed == "" {
+			wl.fixed = word
+		}
+		capitals[capital] = true
+	}
+	for c := range wl.ambiguous {
+		wl.ambiguous[c] = sync.OnceValue(func() string {
+			return ambiguousWord(wl.forms(Capitalization(c)))
+		})
+	}
+	return wl, nil
+}
+
+// forms returns every form a word of wl can take under capitalization c.
+func (wl *Wordlist) forms(c Capitalization) []string {
+	forms := make([]string, 0, 2*len(wl.words))
+	for _, word := range wl.words {
+		if c != CapitalizeWords {
+			forms = append(forms, word)
+		}
+		if capital := capitalizeFirst(word); c == CapitalizeWords || c == CapitalizeRandom && capital != word {
+			forms = append(forms, capital)
+		}
+	}
+	return forms
+}
+
+// ambiguousWord returns a word of words that starts two different sequences
+// of words with the same concatenation, or "" if there is none. It runs the
+// Sardinas-Patterson test for unique decodability: the dangling suffixes
+// left when one sequence is a prefix of another are followed until one of
+// them is a word, which proves the sequences can end together.
+func ambiguousWord(words []string) string {
+	isWord := make(map[string]bool, len(words))
+	for _, word := range words {
+		isWord[word] = true
+	}
+	sorted := slices.Clone(words)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	// origin maps each dangling suffix found so far to the word whose
+	// comparison first produced it.
+	origin := make(map[string]string)
+	var queue []string
+	add := func(suffix, word string) {
+		if _, ok := origin[suffix]; !ok {
+			origin[suffix] = word
+			queue = append(queue, suffix)
+		}
+	}
+	// Suffixes left by a word that is a proper prefix of another word.
+	for _, word := range sorted {
+		for i := 1; i < len(word); i++ {
+			if isWord[word[:i]] {
+				add(word[i:], word[:i])
+			}
+		}
+	}
+	for len(queue) > 0 {
+		suffix := queue[0]
+		queue = queue[1:]
+		if isWord[suffix] {
+			return origin[suffix]
+		}
+		// A word that is a proper prefix of the suffix leaves the rest.
+		for i := 1; i < len(suffix); i++ {
+			if isWord[suffix[:i]] {
+				add(suffix[i:], origin[suffix])
+			}
+		}
+		// The suffix is a proper prefix of a word, leaving the word's rest.
+		i, _ := slices.BinarySearch(sorted, suffix)
+		for ; i < len(sorted) && strings.HasPrefix(sorted[i], suffix); i++ {
+			if len(sorted[i]) > len(suffix) {
+				add(sorted[i][len(suffix):], origin[suffix])
+			}
+		}
+	}
+	return ""
+}
+
+// Len returns the number of words in wl.
+func (wl *Wordlist) Len() int {
+	return len(wl.words)
+}
+
+// Capitalization selects how Passphrase capitalizes words.
+type Capitalization int
+
+const (
+	// CapitalizeNone leaves words as they appear in the wordlist.
+	CapitalizeNone Capitalization = iota
+	// CapitalizeWords capitalizes the first letter of every word. No two
+	// words of the wordlist may have the same capitalized form.
+	CapitalizeWords
+	// CapitalizeRandom capitalizes each word with probability 1/2,
+	// adding one bit of entropy per word. Every word of the wordlist must
+	// start with a letter that has an upper case, and its capitalized form
+	// must not be in the wordlist or shared with another word.
+	CapitalizeRandom
+)
+
+// PassphraseOptions configures Passphrase. The zero value produces
+// lowercase words from the EFF large wordlist separated by spaces.
+type PassphraseOptions struct {
+	// Wordlist is the list words are drawn from. Defaults to EFFLargeWordlist().
+	Wordlist *Wordlist
+	// Separator is placed between words. Defaults to a single space.
+	Separator string
+	// NoSeparator joins words with no separator, as in "CorrectHorseBattery".
+	// Combine it with CapitalizeWords so the words stay distinguishable.
+	// It is rejected for wordlists where two different word sequences can
+	// join to the same string under the chosen capitalization.
+	NoSeparator bool
+	// Capitalize selects the capitalization of words.
+	Capitalize Capitalization
+	// Digit appends a random digit to a randomly chosen word.
+	Digit bool
+	// Symbol appends a random symbol to a randomly chosen word, after the
+	// digit if both land on the same word.
+	Symbol bool
+	// Symbols is the set the symbol is drawn from. Defaults to models.SymbolChars.
+	Symbols models.RuneSet
+}
+
+// withDefaults returns opts with its zero fields replaced by their defaults.
+func (opts PassphraseOptions) withDefaults() PassphraseOptions {
+	if opts.Wordlist == nil {
+		opts.Wordlist = EFFLargeWordlist()
+	}
+	if opts.Separator == "" && !opts.NoSeparator {
+		opts.Separator = " "
+	}
+	if opts.Symbols.Len() == 0 {
+		opts.Symbols = models.SymbolChars
+	}
+	return opts
+}
+
+// Passphrase generates a diceware-style passphrase of words words chosen
+// uniformly and independently from opts.Wordlist, such as
+// "cobalt unsworn gnarly pushover".
+// It returns an error if words <= 0, the capitalization is unknown or
+// unsupported by the wordlist, both Separator and NoSeparator are set, or
+// NoSeparator would let two word sequences join to the same string.
+func (g *Generator) Passphrase(words int, opts PassphraseOptions) (string, error) {
+	if _, err := PassphraseEntropy(words, opts); err != nil {
+		return "", err
+	}
+	opts = opts.withDefaults()
+	list := opts.Wordlist.words
+	result := make([]string, words)
+	for i := range result {
+		idx, err := g.uint64n(uint64(len(list)))
+		if err != nil {
+			return "", err
+		}
+		result[i] = list[idx]
+		capitalize := opts.Capitalize == CapitalizeWords
+		if opts.Capitalize == CapitalizeRandom {
+			coin, err := g.uint64n(2)
+			if err != nil {
+				return "", err
+			}
+			capitalize = coin == 1
+		}
+		if capitalize {
+			result[i] = capitalizeFirst(result[i])
+		}
+	}
+	if opts.Digit {
+		digit, err := g.StringsFrom(1, models.DigitChars)
+		if err != nil {
+			return "", err
+		}
+		if err := g.appendToWord(result, digit); err != nil {
+			return "", err
+		}
+	}
+	if opts.Symbol {
+		symbol, err := g.StringsFrom(1, opts.Symbols)
+		if err != nil {
+			return "", err
+		}
+		if err := g.appendToWord(result, symbol); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(result, opts.Separator), nil
+}
+
+// appendToWord appends s to a uniformly chosen element of words.
+func (g *Generator) appendToWord(words []string, s string) error {
+	idx, err := g.uint64n(uint64(len(words)))
+	if err != nil {
+		return err
+	}
+	words[idx] += s
+	return nil
+}
+
+// PassphraseEntropy returns the entropy in bits of a passphrase generated by
+// Passphrase with the same arguments: log2 of the number of equally likely
+// choices made, i.e. the strength against an attacker who knows the
+// wordlist and options.
+// It returns an error if words <= 0, the capitalization is unknown or
+// unsupported by the wordlist, both Separator and NoSeparator are set, or
+// NoSeparator would let two word sequences join to the same string.
+func PassphraseEntropy(words int, opts PassphraseOptions) (float64, error) {
+	if words <= 0 {
+		return 0, fmt.Errorf("invalid word count: %d", words)
+	}
+	if opts.Capitalize < CapitalizeNone || opts.Capitalize > CapitalizeRandom {
+		return 0, fmt.Errorf("invalid capitalization: %d", opts.Capitalize)
+	}
+	if opts.NoSeparator && opts.Separator != "" {
+		return 0, fmt.Errorf("separator %q set with NoSeparator", opts.Separator)
+	}
+	opts = opts.withDefaults()
+	// Capitalization must keep distinct choices distinct for the counted
+	// entropy to be real.
+	if word := opts.Wordlist.clash; opts.Capitalize != CapitalizeNone && word != "" {
+		return 0, fmt.Errorf("wordlist cannot be capitalized: %q capitalizes to the same word as another", word)
+	}
+	if word := opts.Wordlist.fixed; opts.Capitalize == CapitalizeRandom && word != "" {
+		return 0, fmt.Errorf("wordlist cannot be capitalized at random: %q has no distinct capitalized form", word)
+	}
+	// Without a separator, distinct word sequences must join to distinct strings.
+	if opts.NoSeparator {
+		if word := opts.Wordlist.ambiguous[opts.Capitalize](); word != "" {
+			return 0, fmt.Errorf("wordlist cannot be joined without a separator: sequences starting with %q join ambiguously", word)
+		}
+	}
+	n := float64(words)
+	bits := n * math.Log2(float64(opts.Wordlist.Len()))
+	if opts.Capitalize == CapitalizeRandom {
+		bits += n
+	}
+	if opts.Digit {
+		bits += math.Log2(10) + math.Log2(n)
+	}
+	if opts.Symbol {
+		bits += math.Log2(float64(opts.Symbols.Len())) + math.Log2(n)
+	}
+	return bits, nil
+}
+
+// capitalizeFirst returns s with its first letter in upper case.
+func capitalizeFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,231 @@
+package randutils
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// TestEFFWordlists tests that the embedded wordlists load completely
+func TestEFFWordlists(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  *Wordlist
+		len   int
+		first string
+		last  string
+	}{
+		{"large", EFFLargeWordlist(), 7776, "abacus", "zoom"},
+		{"short", EFFShortWordlist(), 1296, "aardvark", "zucchini"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.list.Len() != tt.len {
+				t.Errorf("Len() = %d, want %d", tt.list.Len(), tt.len)
+			}
+			if tt.list.words[0] != tt.first || tt.list.words[tt.len-1] != tt.last {
+				t.Errorf("words run from %q to %q, want %q to %q", tt.list.words[0], tt.list.words[tt.len-1], tt.first, tt.last)
+			}
+		})
+	}
+}
+
+// TestLoadWordlist tests parsing of plain and diceware-format wordlists
+func TestLoadWordlist(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"plain", "alpha\nbeta\ngamma\n", []string{"alpha", "beta", "gamma"}, false},
+		{"diceware", "11\talpha\n12\tbeta\n", []string{"alpha", "beta"}, false},
+		{"comments and blanks", "# list\n\nalpha\n  beta  \n", []string{"alpha", "beta"}, false},
+		{"numeric word", "alpha\n42\n", []string{"alpha", "42"}, false},
+		{"duplicate", "alpha\nbeta\nalpha\n", nil, true},
+		{"too short", "alpha\n", nil, true},
+		{"phrase", "alpha beta gamma\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wl, err := LoadWordlist(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadWordlist() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && strings.Join(wl.words, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("LoadWordlist() = %v, want %v", wl.words, tt.want)
+			}
+		})
+	}
+	if _, err := LoadWordlist(errSource{}); err == nil {
+		t.Errorf("LoadWordlist() of failing reader error = %v, want error", err)
+	}
+}
+
+// TestPassphrase tests the options of Passphrase
+func TestPassphrase(t *testing.T) {
+	custom, err := LoadWordlist(strings.NewReader("red\ngreen\nblue\n"))
+	if err != nil {
+		t.Fatalf("LoadWordlist() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		words   int
+		opts    PassphraseOptions
+		pattern string
+	}{
+		{"defaults", 6, PassphraseOptions{}, `^[a-z-]+( [a-z-]+){5}$`},
+		{"separator", 4, PassphraseOptions{Separator: "."}, `^[a-z-]+(\.[a-z-]+){3}$`},
+		{"no separator", 4, PassphraseOptions{NoSeparator: true, Capitalize: CapitalizeWords}, `^([A-Z][a-z-]*){4}$`},
+		{"capitalized", 4, PassphraseOptions{Capitalize: CapitalizeWords}, `^[A-Z][a-z-]*( [A-Z][a-z-]*){3}$`},
+		{"random capitals", 4, PassphraseOptions{Capitalize: CapitalizeRandom}, `^[A-Za-z][a-z-]*( [A-Za-z][a-z-]*){3}$`},
+		{"short list", 3, PassphraseOptions{Wordlist: EFFShortWordlist()}, `^[a-z-]+( [a-z-]+){2}$`},
+		{"custom list", 5, PassphraseOptions{Wordlist: custom, Separator: "-"}, `^(red|green|blue)(-(red|green|blue)){4}$`},
+		{"digit", 3, PassphraseOptions{Digit: true}, `^([a-z-]+ ){0,2}[a-z-]+[0-9]( [a-z-]+){0,2}$`},
+		{"symbol", 3, PassphraseOptions{Symbol: true, Symbols: models.NewRuneSet("!")}, `^([a-z-]+ ){0,2}[a-z-]+!( [a-z-]+){0,2}$`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Passphrase(tt.words, tt.opts)
+			if err != nil {
+				t.Fatalf("Passphrase() error = %v", err)
+			}
+			if !regexp.MustCompile(tt.pattern).MatchString(result) {
+				t.Errorf("Passphrase() = %q, want match for %s", result, tt.pattern)
+			}
+		})
+	}
+
+	for _, words := range []int{0, -1} {
+		if _, err := Passphrase(words, PassphraseOptions{}); err == nil {
+			t.Errorf("Passphrase(%d) error = nil, want error", words)
+		}
+	}
+	if _, err := Passphrase(3, PassphraseOptions{Capitalize: 7}); err == nil {
+		t.Errorf("Passphrase() with unknown capitalization error = nil, want error")
+	}
+	if _, err := Passphrase(3, PassphraseOptions{Separator: "-", NoSeparator: true}); err == nil {
+		t.Errorf("Passphrase() with Separator and NoSeparator error = nil, want error")
+	}
+	if _, err := NewSeeded(testSeed()).Passphrase(3, PassphraseOptions{}); err != nil {
+		t.Errorf("Passphrase() with a seeded generator error = %v", err)
+	}
+	if _, err := New(errSource{}).Passphrase(3, PassphraseOptions{}); err == nil {
+		t.Errorf("Passphrase() with a failing source error = nil, want error")
+	}
+}
+
+// TestPassphraseEntropy tests the reported entropy of passphrase options
+func TestPassphraseEntropy(t *testing.T) {
+	tests := []struct {
+		name  string
+		words int
+		opts  PassphraseOptions
+		want  float64
+	}{
+		{"six large words", 6, PassphraseOptions{}, 6 * math.Log2(7776)},
+		{"four short words", 4, PassphraseOptions{Wordlist: EFFShortWordlist()}, 4 * math.Log2(1296)},
+		{"capitalized words add nothing", 5, PassphraseOptions{Capitalize: CapitalizeWords}, 5 * math.Log2(7776)},
+		{"random capitals", 5, PassphraseOptions{Capitalize: CapitalizeRandom}, 5*math.Log2(7776) + 5},
+		{"digit and symbol", 4, PassphraseOptions{Digit: true, Symbol: true}, 4*math.Log2(7776) + math.Log2(10) + math.Log2(32) + 2*math.Log2(4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PassphraseEntropy(tt.words, tt.opts)
+			if err != nil {
+				t.Fatalf("PassphraseEntropy() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PassphraseEntropy() = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPassphraseEntropy_Capitalization tests that capitalization is rejected
+// for wordlists where it would not add the entropy counted for it
+func TestPassphraseEntropy_Capitalization(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		words, random  bool // whether CapitalizeWords and CapitalizeRandom are accepted
+		wantRandomBits float64
+	}{
+		{"lowercase", "red\ngreen\nblue\n", true, true, 3 * (math.Log2(3) + 1)},
+		{"digit", "red\n42\n", true, false, 0},
+		{"sharp s", "red\nßig\n", true, false, 0},
+		{"capital in list", "apple\nApple\npear\n", false, false, 0},
+		{"capital first", "Apple\napple\npear\n", false, false, 0},
+		{"already capitalized", "Red\nGreen\n", true, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wl, err := LoadWordlist(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("LoadWordlist() error = %v", err)
+			}
+			_, err = PassphraseEntropy(3, PassphraseOptions{Wordlist: wl, Capitalize: CapitalizeWords})
+			if (err == nil) != tt.words {
+				t.Errorf("PassphraseEntropy(CapitalizeWords) error = %v, want accepted %v", err, tt.words)
+			}
+			bits, err := PassphraseEntropy(3, PassphraseOptions{Wordlist: wl, Capitalize: CapitalizeRandom})
+			if (err == nil) != tt.random {
+				t.Errorf("PassphraseEntropy(CapitalizeRandom) error = %v, want accepted %v", err, tt.random)
+			}
+			if err == nil && math.Abs(bits-tt.wantRandomBits) > 1e-9 {
+				t.Errorf("PassphraseEntropy(CapitalizeRandom) = %.4f, want %.4f", bits, tt.wantRandomBits)
+			}
+			if _, err := Passphrase(3, PassphraseOptions{Wordlist: wl, Capitalize: CapitalizeRandom}); (err == nil) != tt.random {
+				t.Errorf("Passphrase(CapitalizeRandom) error = %v, want accepted %v", err, tt.random)
+			}
+		})
+	}
+}
+
+// TestPassphraseEntropy_NoSeparator tests that NoSeparator is rejected for
+// wordlists where two word sequences can join to the same string
+func TestPassphraseEntropy_NoSeparator(t *testing.T) {
+	tests := []struct {
+		name                string
+		input               string
+		none, words, random bool // whether each capitalization is accepted
+	}{
+		{"prefix free", "red\ngreen\nblue\n", true, true, true},
+		{"word splits", "a\nab\nb\n", false, true, false},
+		{"suffix chain", "ab\nabc\ncd\nd\n", false, true, false},
+		{"capital inside", "ab\nc\nabC\n", true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wl, err := LoadWordlist(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("LoadWordlist() error = %v", err)
+			}
+			for c, want := range []bool{tt.none, tt.words, tt.random} {
+				opts := PassphraseOptions{Wordlist: wl, NoSeparator: true, Capitalize: Capitalization(c)}
+				if _, err := PassphraseEntropy(3, opts); (err == nil) != want {
+					t.Errorf("PassphraseEntropy(Capitalize %d) error = %v, want accepted %v", c, err, want)
+				}
+				if _, err := Passphrase(3, opts); (err == nil) != want {
+					t.Errorf("Passphrase(Capitalize %d) error = %v, want accepted %v", c, err, want)
+				}
+			}
+		})
+	}
+
+	// The embedded lists join unambiguously even without capitalization.
+	for _, wl := range []*Wordlist{EFFLargeWordlist(), EFFShortWordlist()} {
+		if _, err := PassphraseEntropy(6, PassphraseOptions{Wordlist: wl, NoSeparator: true}); err != nil {
+			t.Errorf("PassphraseEntropy() of %d-word list error = %v, want nil", wl.Len(), err)
+		}
+	}
+}
@@ -0,0 +1,484 @@
+package randutils
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// PasswordClass requires at least Min characters of a password to come from Set.
+type PasswordClass struct {
+	Set models.RuneSet
+	Min int
+}
+
+// PasswordPolicy describes the passwords accepted by a downstream system.
+// Every character of a password belongs to exactly one of Classes, which
+// must be disjoint.
+type PasswordPolicy struct {
+	// Length is the number of characters in the password.
+	Length int
+	// Classes are the character sets passwords are drawn from, each with a
+	// minimum number of occurrences.
+	Classes []PasswordClass
+	// Exclude lists characters removed from every class.
+	Exclude string
+	// NoRepeat forbids using any character more than once.
+	NoRepeat bool
+	// MaxConsecutive limits runs of the same character, e.g. 2 allows "aa"
+	// but not "aaa". Zero means no limit.
+	MaxConsecutive int
+}
+
+// DefaultPasswordPolicy returns a policy for 16-character passwords with at
+// least one uppercase letter, lowercase letter, digit and symbol.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		Length: 16,
+		Classes: []PasswordClass{
+			{Set: models.UpperChars, Min: 1},
+			{Set: models.LowerChars, Min: 1},
+			{Set: models.DigitChars, Min: 1},
+			{Set: models.SymbolChars, Min: 1},
+		},
+	}
+}
+
+const (
+	// maxPasswordLength is the largest Length a policy may ask for.
+	maxPasswordLength = 256
+	// maxRunStates bounds the size of the counting table built for
+	// MaxConsecutive, which grows with Length and the class minimums.
+	maxRunStates = 1 << 17
+)
+
+// PasswordSpec generates passwords accepted by a compiled PasswordPolicy
+// without rebuilding its counting tables. It is immutable and safe for
+// concurrent use.
+type PasswordSpec struct {
+	length   int
+	noRepeat bool
+	sets     []models.RuneSet
+	// Exactly one of table and runs is set: runs when MaxConsecutive can
+	// reject a password, table otherwise.
+	table *passwordTable
+	runs  *runTable
+}
+
+// CompilePassword validates policy and builds the tables Password uses to
+// count and draw the passwords it accepts. Every password the policy accepts
+// is equally likely, and no rule is enforced by retrying:
+//
+// Without MaxConsecutive, the number of valid passwords with each class
+// composition is counted exactly, a composition is drawn with probability
+// proportional to its count, and the positions and characters of each class
+// are then drawn uniformly. With MaxConsecutive, passwords are counted as
+// sequences of runs of one character, tracking the characters each class
+// still needs, and drawn one run at a time in proportion to the number of
+// passwords each choice leaves possible.
+//
+// It returns an error if the policy is invalid, Length exceeds 256, the
+// MaxConsecutive table would be too large, or no password satisfies it.
+func CompilePassword(policy PasswordPolicy) (*PasswordSpec, error) {
+	sets, err := policy.sets()
+	if err != nil {
+		return nil, err
+	}
+	s := &PasswordSpec{length: policy.Length, noRepeat: policy.NoRepeat, sets: sets}
+	// NoRepeat already keeps every run to one character.
+	if policy.MaxConsecutive > 0 && policy.MaxConsecutive < policy.Length && !policy.NoRepeat {
+		if s.runs, err = newRunTable(sets, policy); err != nil {
+			return nil, err
+		}
+	} else {
+		s.table = newPasswordTable(sets, policy)
+	}
+	if s.Keyspace().Sign() == 0 {
+		return nil, fmt.Errorf("no password satisfies the policy")
+	}
+	return s, nil
+}
+
+// Keyspace returns the number of distinct passwords s can generate.
+func (s *PasswordSpec) Keyspace() *big.Int {
+	if s.runs != nil {
+		return new(big.Int).Set(&s.runs.ways[s.runs.start])
+	}
+	return new(big.Int).Set(s.table.ways[0][s.length])
+}
+
+// Generate returns a random password accepted by the policy of s.
+func (s *PasswordSpec) Generate() (string, error) {
+	return s.GenerateWith(defaultGenerator)
+}
+
+// GenerateWith is like Generate but draws from g.
+func (s *PasswordSpec) GenerateWith(g *Generator) (string, error) {
+	var password []rune
+	var err error
+	if s.runs != nil {
+		password, err = g.runPassword(s)
+	} else {
+		password, err = g.composePassword(s)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(password), nil
+}
+
+// Password generates a random password satisfying policy. Every password the
+// policy accepts is equally likely; see CompilePassword for the method.
+// Compile the policy once with CompilePassword to generate many passwords.
+//
+// It returns an error if the policy is invalid or no password satisfies it.
+func (g *Generator) Password(policy PasswordPolicy) (string, error) {
+	s, err := CompilePassword(policy)
+	if err != nil {
+		return "", err
+	}
+	return s.GenerateWith(g)
+}
+
+// sets validates policy and returns its class sets with the excluded characters removed.
+func (p PasswordPolicy) sets() ([]models.RuneSet, error) {
+	if p.Length <= 0 || p.Length > maxPasswordLength {
+		return nil, fmt.Errorf("invalid length: %d", p.Length)
+	}
+	if len(p.Classes) == 0 {
+		return nil, fmt.Errorf("password policy has no character classes")
+	}
+	if p.MaxConsecutive < 0 {
+		return nil, fmt.Errorf("invalid max consecutive: %d", p.MaxConsecutive)
+	}
+	exclude := models.NewRuneSet(p.Exclude)
+	sets := make([]models.RuneSet, len(p.Classes))
+	for i, class := range p.Classes {
+		if class.Min < 0 {
+			return nil, fmt.Errorf("invalid minimum for class %d: %d", i, class.Min)
+		}
+		sets[i] = class.Set.Subtract(exclude)
+		for j := range i {
+			if sets[i].Intersect(sets[j]).Len() != 0 {
+				return nil, fmt.Errorf("password classes %d and %d overlap", j, i)
+			}
+		}
+	}
+	return sets, nil
+}
+
+// passwordTable counts the passwords a policy accepts, split by how many
+// characters each class contributes.
+type passwordTable struct {
+	// fill[i][c] is the number of ways to fill c given positions from class i:
+	// |set|^c, or the falling factorial |set|!/(|set|-c)! when characters
+	// may not repeat.
+	fill [][]*big.Int
+	// ways[i][r] is the number of ways to fill r positions with characters
+	// of classes i and later while meeting their minimums.
+	// ways[0][Length] counts every valid password.
+	ways [][]*big.Int
+	mins []int
+}
+
+// newPasswordTable builds the counting table for sets under policy.
+func newPasswordTable(sets []models.RuneSet, policy PasswordPolicy) *passwordTable {
+	m, length := len(sets), policy.Length
+	t := &passwordTable{
+		fill: make([][]*big.Int, m),
+		ways: make([][]*big.Int, m+1),
+		mins: make([]int, m),
+	}
+	for i, set := range sets {
+		t.mins[i] = policy.Classes[i].Min
+		n := int64(set.Len())
+		t.fill[i] = make([]*big.Int, length+1)
+		t.fill[i][0] = big.NewInt(1)
+		for c := 1; c <= length; c++ {
+			factor := n
+			if policy.NoRepeat {
+				factor = max(n-int64(c-1), 0)
+			}
+			t.fill[i][c] = new(big.Int).Mul(t.fill[i][c-1], big.NewInt(factor))
+		}
+	}
+	t.ways[m] = make([]*big.Int, length+1)
+	for r := range length + 1 {
+		t.ways[m][r] = new(big.Int)
+	}
+	t.ways[m][0].SetInt64(1)
+	for i := m - 1; i >= 0; i-- {
+		t.ways[i] = make([]*big.Int, length+1)
+		for r := range length + 1 {
+			total := new(big.Int)
+			t.terms(i, r, func(_ int, w *big.Int) bool {
+				total.Add(total, w)
+				return true
+			})
+			t.ways[i][r] = total
+		}
+	}
+	return t
+}
+
+// terms calls yield, for each count c from the minimum of class i up to r,
+// with the number of ways to fill r positions when class i takes exactly c
+// of them: C(r, c) * fill[i][c] * ways[i+1][r-c]. It stops if yield returns
+// false. The value passed to yield is reused between calls.
+func (t *passwordTable) terms(i, r int, yield func(c int, w *big.Int) bool) {
+	c := t.mins[i]
+	if c > r {
+		return
+	}
+	binom := new(big.Int).Binomial(int64(r), int64(c))
+	w := new(big.Int)
+	for ; c <= r; c++ {
+		if c > t.mins[i] {
+			// C(r, c) = C(r, c-1) * (r-c+1) / c
+			binom.Mul(binom, big.NewInt(int64(r-c+1)))
+			binom.Quo(binom, big.NewInt(int64(c)))
+		}
+		w.Mul(binom, t.fill[i][c])
+		w.Mul(w, t.ways[i+1][r-c])
+		if !yield(c, w) {
+			return
+		}
+	}
+}
+
+// composePassword draws a password uniformly among those meeting the
+// minimums and NoRepeat of s, one class at a time.
+func (g *Generator) composePassword(s *PasswordSpec) ([]rune, error) {
+	table := s.table
+	password := make([]rune, s.length)
+	free := identity(s.length) // positions not yet assigned to a class
+	for i, set := range s.sets {
+		r := len(free)
+		// Draw the number of characters c of this class with probability
+		// proportional to the number of passwords it leaves possible.
+		x, err := g.BigInt(table.ways[i][r])
+		if err != nil {
+			return nil, err
+		}
+		c := r
+		table.terms(i, r, func(k int, w *big.Int) bool {
+			if x.Cmp(w) < 0 {
+				c = k
+				return false
+			}
+			x.Sub(x, w)
+			return true
+		})
+		if c == 0 {
+			continue
+		}
+
+		// Give the class a uniform choice of c free positions.
+		positions := free
+		if c < r {
+			idx, err := sampleIndices(g, r, c)
+			if err != nil {
+				return nil, err
+			}
+			positions = make([]int, c)
+			taken := make([]bool, r)
+			for k, j := range idx {
+				positions[k] = free[j]
+				taken[j] = true
+			}
+			rest := make([]int, 0, r-c)
+			for j, pos := range free {
+				if !taken[j] {
+					rest = append(rest, pos)
+				}
+			}
+			free = rest
+		} else {
+			free = nil
+		}
+
+		// Fill the positions uniformly, with distinct characters if required.
+		if s.noRepeat {
+			idx, err := sampleIndices(g, set.Len(), c)
+			if err != nil {
+				return nil, err
+			}
+			for k, pos := range positions {
+				password[pos] = set.At(idx[k])
+			}
+		} else {
+			runes, err := g.RandomRunes(c, set)
+			if err != nil {
+				return nil, err
+			}
+			for k, pos := range positions {
+				password[pos] = runes[k]
+			}
+		}
+	}
+	return password, nil
+}
+
+// runTable counts the passwords of a policy with MaxConsecutive as
+// sequences of blocks: runs of one character, 1 to maxRun long, each using a
+// different character from the block before it.
+//
+// A state (r, d, last) is r positions left to fill, a deficit vector d of
+// the characters each class still needs, and the class of the previous
+// block, or len(sizes) at the start. Deficit vectors are indexed in mixed
+// radix: class i contributes d_i * strides[i], with d_i <= limits[i].
+type runTable struct {
+	sizes   []int64 // number of characters of each class
+	maxRun  int
+	strides []int
+	limits  []int
+	nd      int // number of deficit vectors
+	d0      int // deficit vector of an empty password
+	start   int // state of an empty password
+	// ways[state] is the number of ways to fill the remaining positions.
+	ways []big.Int
+	// prefix[state(r, d, last)] is the sum of ways[state(k, d, last)] for k <= r.
+	prefix []big.Int
+}
+
+// newRunTable builds the counting table for sets under policy, or returns an
+// error if it would have more than maxRunStates states.
+func newRunTable(sets []models.RuneSet, policy PasswordPolicy) (*runTable, error) {
+	m, length := len(sets), policy.Length
+	t := &runTable{
+		sizes:   make([]int64, m),
+		maxRun:  policy.MaxConsecutive,
+		strides: make([]int, m),
+		limits:  make([]int, m),
+		nd:      1,
+	}
+	perDeficit := (length + 1) * (m + 1)
+	for i, set := range sets {
+		t.sizes[i] = int64(set.Len())
+		t.limits[i] = min(policy.Classes[i].Min, length)
+		t.strides[i] = t.nd
+		t.d0 += t.limits[i] * t.nd
+		if t.nd > maxRunStates/perDeficit/(t.limits[i]+1) {
+			return nil, fmt.Errorf("password policy is too large to enforce max consecutive: reduce the length or class minimums")
+		}
+		t.nd *= t.limits[i] + 1
+	}
+	t.ways = make([]big.Int, t.nd*perDeficit)
+	t.prefix = make([]big.Int, t.nd*perDeficit)
+	blocks := make([]*big.Int, m)
+	total, term := new(big.Int), new(big.Int)
+	for r := range length + 1 {
+		for d := range t.nd {
+			// ways(r, d, last) = sum over classes j of the characters allowed
+			// after last times blocks(r, d, j): every class offers all its
+			// characters except the one the previous block used.
+			total.SetInt64(0)
+			if r == 0 && d == 0 {
+				total.SetInt64(1)
+			}
+			if r > 0 {
+				for j := range m {
+					blocks[j] = t.blocks(r, d, j)
+					total.Add(total, term.Mul(blocks[j], big.NewInt(t.sizes[j])))
+				}
+			}
+			for last := range m + 1 {
+				k := t.state(r, d, last)
+				t.ways[k].Set(total)
+				if r > 0 && last < m {
+					t.ways[k].Sub(&t.ways[k], blocks[last])
+				}
+				t.prefix[k].Set(&t.ways[k])
+				if r > 0 {
+					t.prefix[k].Add(&t.prefix[k], &t.prefix[t.state(r-1, d, last)])
+				}
+			}
+		}
+	}
+	t.start = t.state(length, t.d0, m)
+	return t, nil
+}
+
+// state returns the index of state (r, d, last) in ways and prefix.
+func (t *runTable) state(r, d, last int) int {
+	return (r*t.nd+d)*(len(t.sizes)+1) + last
+}
+
+// deficit returns the deficit of class j in the deficit vector d.
+func (t *runTable) deficit(d, j int) int {
+	return d / t.strides[j] % (t.limits[j] + 1)
+}
+
+// blocks returns the number of ways to fill r positions with deficits d when
+// the first block is a given character of class j: the sum over block
+// lengths l of ways(r-l, d', j), where d' lowers the deficit of j by l.
+func (t *runTable) blocks(r, d, j int) *big.Int {
+	sum := new(big.Int)
+	hi, dj := min(t.maxRun, r), t.deficit(d, j)
+	// Blocks shorter than the deficit of j each leave a different deficit.
+	for l := 1; l < dj && l <= hi; l++ {
+		sum.Add(sum, &t.ways[t.state(r-l, d-l*t.strides[j], j)])
+	}
+	// Longer blocks all clear it, so their states are a range of prefix.
+	if lo := max(dj, 1); lo <= hi {
+		cleared := d - dj*t.strides[j]
+		sum.Add(sum, &t.prefix[t.state(r-lo, cleared, j)])
+		if r-hi > 0 {
+			sum.Sub(sum, &t.prefix[t.state(r-hi-1, cleared, j)])
+		}
+	}
+	return sum
+}
+
+// runPassword draws a password uniformly among those accepted by s, one
+// block at a time. Each draw selects the class, character and length of the
+// next block with probability proportional to the passwords it leaves possible.
+func (g *Generator) runPassword(s *PasswordSpec) ([]rune, error) {
+	t := s.runs
+	password := make([]rune, 0, s.length)
+	r, d, last, prev := s.length, t.d0, len(t.sizes), 0
+	for r > 0 {
+		x, err := g.BigInt(&t.ways[t.state(r, d, last)])
+		if err != nil {
+			return nil, err
+		}
+		for j := range t.sizes {
+			choices := t.sizes[j]
+			if j == last {
+				choices--
+			}
+			if choices == 0 {
+				continue
+			}
+			blocks := t.blocks(r, d, j)
+			w := new(big.Int).Mul(blocks, big.NewInt(choices))
+			if x.Cmp(w) >= 0 {
+				x.Sub(x, w)
+				continue
+			}
+			// x = y*choices + c, with c uniform over the allowed characters
+			// and y uniform over the blocks(r, d, j) continuations.
+			y, c := new(big.Int).QuoRem(x, big.NewInt(choices), new(big.Int))
+			idx := int(c.Int64())
+			if j == last && idx >= prev {
+				idx++ // skip the character of the previous block
+			}
+			hi, dj := min(t.maxRun, r), t.deficit(d, j)
+			l := 1
+			for ; l < hi; l++ {
+				w := &t.ways[t.state(r-l, d-min(l, dj)*t.strides[j], j)]
+				if y.Cmp(w) < 0 {
+					break
+				}
+				y.Sub(y, w)
+			}
+			for range l {
+				password = append(password, s.sets[j].At(idx))
+			}
+			r, d, last, prev = r-l, d-min(l, dj)*t.strides[j], j, idx
+			break
+		}
+	}
+	return password, nil
+}
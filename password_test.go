@@ -0,0 +1,303 @@
+package randutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// TestPassword_Default tests that the default policy yields every character class
+func TestPassword_Default(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	g := NewSeeded(testSeed())
+	for range 500 {
+		password, err := g.Password(policy)
+		if err != nil {
+			t.Fatalf("Password() error = %v", err)
+		}
+		if len(password) != policy.Length {
+			t.Fatalf("Password() = %q, length %d, want %d", password, len(password), policy.Length)
+		}
+		for _, class := range policy.Classes {
+			if !strings.ContainsFunc(password, class.Set.Contains) {
+				t.Fatalf("Password() = %q, missing a character of %q", password, class.Set)
+			}
+		}
+	}
+}
+
+// TestPassword_Uniform tests that every valid password of a small policy is equally likely
+func TestPassword_Uniform(t *testing.T) {
+	letters, digits := models.NewRuneSet("ab"), models.NewRuneSet("01")
+	base := PasswordPolicy{
+		Length:  3,
+		Classes: []PasswordClass{{Set: letters, Min: 1}, {Set: digits, Min: 1}},
+	}
+	noRepeat := base
+	noRepeat.NoRepeat = true
+	noRuns := base
+	noRuns.MaxConsecutive = 1
+	// Valid passwords among the 4^3 strings over "ab01": base excludes the
+	// 2^3 all-letter and 2^3 all-digit strings; noRepeat keeps the 4*3*2
+	// strings of distinct characters; noRuns keeps the 4*3*3 strings without
+	// adjacent repeats except the 2 alternating all-letter and 2 all-digit ones.
+	// The 52 passwords of "max consecutive two" have at least two letters, a
+	// zero and no character three times in a row.
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+		cells  int
+	}{
+		{"minimums", base, 48},
+		{"no repeat", noRepeat, 24},
+		{"max consecutive", noRuns, 32},
+		{"max consecutive two", PasswordPolicy{
+			Length:         4,
+			Classes:        []PasswordClass{{Set: models.NewRuneSet("ab"), Min: 2}, {Set: models.NewRuneSet("0"), Min: 1}},
+			MaxConsecutive: 2,
+		}, 52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples := 500 * tt.cells
+			g := NewSeeded(testSeed())
+			counts := make(map[string]int)
+			for range samples {
+				password, err := g.Password(tt.policy)
+				if err != nil {
+					t.Fatalf("Password() error = %v", err)
+				}
+				counts[password]++
+			}
+			if len(counts) != tt.cells {
+				t.Fatalf("Password() produced %d distinct passwords, want %d", len(counts), tt.cells)
+			}
+			if chi2, limit := chiSquareUniform(counts, tt.cells, samples), chiSquareLimit(tt.cells-1); chi2 > limit {
+				t.Errorf("chi-square = %.2f, want <= %.2f", chi2, limit)
+			}
+		})
+	}
+}
+
+// TestCompilePassword_Keyspace tests the exact password counts against brute force enumeration
+func TestCompilePassword_Keyspace(t *testing.T) {
+	letters, digits, symbol := models.NewRuneSet("abc"), models.NewRuneSet("01"), models.NewRuneSet("!")
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+	}{
+		{"minimums", PasswordPolicy{Length: 5, Classes: []PasswordClass{{Set: letters, Min: 2}, {Set: digits, Min: 1}}}},
+		{"no repeat", PasswordPolicy{Length: 4, Classes: []PasswordClass{{Set: letters, Min: 1}, {Set: digits, Min: 1}}, NoRepeat: true}},
+		{"runs of one", PasswordPolicy{Length: 6, Classes: []PasswordClass{{Set: letters, Min: 2}, {Set: symbol, Min: 1}}, MaxConsecutive: 1}},
+		{"runs of two", PasswordPolicy{Length: 6, Classes: []PasswordClass{{Set: letters, Min: 1}, {Set: digits, Min: 3}, {Set: symbol}}, MaxConsecutive: 2}},
+		{"runs of three", PasswordPolicy{Length: 7, Classes: []PasswordClass{{Set: digits, Min: 4}, {Set: symbol, Min: 2}}, MaxConsecutive: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := CompilePassword(tt.policy)
+			if err != nil {
+				t.Fatalf("CompilePassword() error = %v", err)
+			}
+			if got, want := spec.Keyspace().Int64(), countPasswords(tt.policy); got != want {
+				t.Errorf("Keyspace() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+// countPasswords counts the passwords policy accepts by enumerating every
+// string over the union of its classes.
+func countPasswords(policy PasswordPolicy) int64 {
+	var all []rune
+	for _, class := range policy.Classes {
+		all = append(all, class.Set.Runes()...)
+	}
+	var count int64
+	password := make([]rune, policy.Length)
+	var fill func(i int)
+	fill = func(i int) {
+		if i < policy.Length {
+			for _, r := range all {
+				password[i] = r
+				fill(i + 1)
+			}
+			return
+		}
+		if policy.NoRepeat && models.NewRuneSet(string(password)).Len() != policy.Length {
+			return
+		}
+		if policy.MaxConsecutive > 0 && longestRun(password) > policy.MaxConsecutive {
+			return
+		}
+		for _, class := range policy.Classes {
+			n := 0
+			for _, r := range password {
+				if class.Set.Contains(r) {
+					n++
+				}
+			}
+			if n < class.Min {
+				return
+			}
+		}
+		count++
+	}
+	fill(0)
+	return count
+}
+
+// TestPassword_LongRuns tests that MaxConsecutive never fails on long policies
+// that few random strings satisfy
+func TestPassword_LongRuns(t *testing.T) {
+	g := NewSeeded(testSeed())
+	for _, length := range []int{64, 96, maxPasswordLength} {
+		spec, err := CompilePassword(PasswordPolicy{
+			Length:         length,
+			Classes:        []PasswordClass{{Set: models.DigitChars, Min: 1}},
+			MaxConsecutive: 1,
+		})
+		if err != nil {
+			t.Fatalf("CompilePassword(length %d) error = %v", length, err)
+		}
+		for range 50 {
+			password, err := spec.GenerateWith(g)
+			if err != nil {
+				t.Fatalf("GenerateWith() error = %v", err)
+			}
+			if len(password) != length || longestRun([]rune(password)) > 1 {
+				t.Fatalf("GenerateWith() = %q, want %d digits without repeats", password, length)
+			}
+		}
+	}
+}
+
+// TestPassword_Rules tests the Exclude, NoRepeat and MaxConsecutive rules
+func TestPassword_Rules(t *testing.T) {
+	policy := PasswordPolicy{
+		Length: 30,
+		Classes: []PasswordClass{
+			{Set: models.AlnumChars, Min: 20},
+			{Set: models.SymbolChars, Min: 2},
+		},
+		Exclude:        "0O1lI",
+		MaxConsecutive: 1,
+	}
+	g := NewSeeded(testSeed())
+	for range 200 {
+		password, err := g.Password(policy)
+		if err != nil {
+			t.Fatalf("Password() error = %v", err)
+		}
+		if strings.ContainsAny(password, policy.Exclude) {
+			t.Fatalf("Password() = %q, contains an excluded character", password)
+		}
+		if longestRun([]rune(password)) > 1 {
+			t.Fatalf("Password() = %q, has a repeated run", password)
+		}
+	}
+
+	policy = PasswordPolicy{
+		Length:   10,
+		Classes:  []PasswordClass{{Set: models.NewRuneSet("abcdefghij"), Min: 0}},
+		NoRepeat: true,
+	}
+	for range 100 {
+		password, err := g.Password(policy)
+		if err != nil {
+			t.Fatalf("Password() error = %v", err)
+		}
+		if models.NewRuneSet(password).Len() != 10 {
+			t.Fatalf("Password() = %q, repeats a character", password)
+		}
+	}
+}
+
+// TestPassword_Errors tests the validation of password policies
+func TestPassword_Errors(t *testing.T) {
+	valid := DefaultPasswordPolicy()
+	tests := []struct {
+		name   string
+		modify func(p *PasswordPolicy)
+	}{
+		{"zero length", func(p *PasswordPolicy) { p.Length = 0 }},
+		{"too long", func(p *PasswordPolicy) { p.Length = maxPasswordLength + 1 }},
+		{"too large for max consecutive", func(p *PasswordPolicy) {
+			p.Length = maxPasswordLength
+			for i := range p.Classes {
+				p.Classes[i].Min = 8
+			}
+			p.MaxConsecutive = 2
+		}},
+		{"no classes", func(p *PasswordPolicy) { p.Classes = nil }},
+		{"negative minimum", func(p *PasswordPolicy) { p.Classes = []PasswordClass{{Set: models.LowerChars, Min: -1}} }},
+		{"negative max consecutive", func(p *PasswordPolicy) { p.MaxConsecutive = -1 }},
+		{"overlapping classes", func(p *PasswordPolicy) {
+			p.Classes = []PasswordClass{{Set: models.AlnumChars, Min: 1}, {Set: models.DigitChars, Min: 1}}
+		}},
+		{"minimums exceed length", func(p *PasswordPolicy) { p.Length = 3 }},
+		{"class excluded entirely", func(p *PasswordPolicy) { p.Exclude = "0123456789" }},
+		{"too few characters to not repeat", func(p *PasswordPolicy) {
+			p.Classes = []PasswordClass{{Set: models.DigitChars, Min: 1}}
+			p.NoRepeat = true
+		}},
+		{"unsatisfiable runs", func(p *PasswordPolicy) {
+			p.Classes = []PasswordClass{{Set: models.NewRuneSet("x"), Min: 1}}
+			p.MaxConsecutive = 1
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid
+			policy.Classes = append([]PasswordClass(nil), valid.Classes...)
+			tt.modify(&policy)
+			if _, err := Password(policy); err == nil {
+				t.Errorf("Password() error = nil, want error")
+			}
+		})
+	}
+}
+
+// longestRun returns the length of the longest run of identical runes in s.
+func longestRun(s []rune) int {
+	longest, run := 0, 0
+	for i, r := range s {
+		if i > 0 && r == s[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// TestPassword_SeededGolden pins seeded password output so changes to the
+// sampler or across Go versions are caught
+func TestPassword_SeededGolden(t *testing.T) {
+	seed := testSeed()
+	policy := DefaultPasswordPolicy()
+	for _, tt := range []struct {
+		maxConsecutive int
+		want           string
+	}{
+		{0, `UU<CP"qDfW,vE15(`},
+		{1, `YXx;i)8C+?r$>WV|`},
+	} {
+		policy.MaxConsecutive = tt.maxConsecutive
+		spec, err := CompilePassword(policy)
+		if err != nil {
+			t.Fatalf("CompilePassword() error = %v", err)
+		}
+		password, err := spec.GenerateWith(NewSeeded(seed))
+		if err != nil {
+			t.Fatalf("GenerateWith() error = %v", err)
+		}
+		if password != tt.want {
+			t.Errorf("GenerateWith(NewSeeded(%s)) with MaxConsecutive %d = %q, want %q", seed, tt.maxConsecutive, password, tt.want)
+		}
+	}
+}
@@ -1,147 +1,242 @@
 // Package randutils provides cryptographically secure random generation utilities.
 // It offers functions for generating random integers, strings, UUIDs, and encoded random data.
+//
+// The package-level functions draw from crypto/rand. Use New to build a
+// Generator over any other io.Reader source.
 package randutils
 
 import (
-	crand "crypto/rand"
-	"encoding/base64"
-	"fmt"
-	"io"
 	"math/big"
 
 	"github.com/chaosoffire/go-randutils/models"
+	"github.com/chaosoffire/go-randutils/uuid"
 )
 
 // Int returns a random integer in the range [0, max) (min inclusive, max exclusive).
 // It uses cryptographic randomness and returns an error if max <= 0 or on crypto/rand failure.
 func Int(max int) (int, error) {
-	if max <= 0 {
-		return 0, fmt.Errorf("invalid max: %d", max)
-	}
-	result, err := crand.Int(crand.Reader, big.NewInt(int64(max)))
-	if err != nil {
-		return 0, fmt.Errorf("crypto/rand error: %w", err)
-	}
-	return int(result.Int64()), nil
+	return defaultGenerator.Int(max)
 }
 
 // IntRange returns a random integer in the range [min, max) (min inclusive, max exclusive).
 func IntRange(min, max int) (int, error) {
-	if min >= max {
-		return 0, fmt.Errorf("invalid range: min %d >= max %d", min, max)
-	}
-	n := max - min
-	result, err := Int(n)
-	if err != nil {
-		return 0, err
-	}
-	return result + min, nil
+	return defaultGenerator.IntRange(min, max)
+}
+
+// Int64Range returns a random int64 in the range [min, max) (min inclusive, max exclusive).
+// It handles every range with min < max, including the full int64 span.
+func Int64Range(min, max int64) (int64, error) {
+	return defaultGenerator.Int64Range(min, max)
+}
+
+// Uint64Range returns a random uint64 in the range [min, max) (min inclusive, max exclusive).
+// It handles every range with min < max, including the full uint64 span.
+func Uint64Range(min, max uint64) (uint64, error) {
+	return defaultGenerator.Uint64Range(min, max)
 }
 
 // Random generates a random sequence of integers by selecting from the provided charset.
-// It uses IntRange to properly sample from the charset, ensuring all elements have equal probability.
+// Every element of charset is selected with equal probability.
+// Use RandomFrom or RandomRunes to select from a models.RuneSet.
 func Random(length int, charset []int) ([]int, error) {
-	if length <= 0 {
-		return nil, fmt.Errorf("invalid length: %d", length)
-	}
-	lengthSet := len(charset)
-	if lengthSet == 0 {
-		return nil, fmt.Errorf("charset is empty")
-	}
-	b := make([]int, 0, length)
-	for range length {
-		idx, err := IntRange(0, lengthSet)
-		if err != nil {
-			return nil, err
-		}
-		b = append(b, charset[idx])
-	}
-	return b, nil
+	return defaultGenerator.Random(length, charset)
 }
 
 // Strings generates a random string of specified length using alphanumeric characters (A-Z, a-z, 0-9).
+// Use StringsFrom to choose the characters from a models.RuneSet.
 // Returns an error if length <= 0 or if random generation fails.
 func Strings(length int) (string, error) {
-	if length <= 0 {
-		return "", fmt.Errorf("invalid length: %d", length)
-	}
-	randomInts, err := Random(length, models.Charset)
-	if err != nil {
-		return "", err
-	}
-	return toASCII(randomInts), nil
+	return defaultGenerator.Strings(length)
+}
+
+// RandomFrom generates a random sequence of code points by selecting from charset.
+// Every member of charset has equal probability.
+func RandomFrom(length int, charset models.RuneSet) ([]int, error) {
+	return defaultGenerator.RandomFrom(length, charset)
+}
+
+// RandomRunes generates a random sequence of runes by selecting from charset.
+// Unlike Random, it is not limited to ASCII.
+func RandomRunes(length int, charset models.RuneSet) ([]rune, error) {
+	return defaultGenerator.RandomRunes(length, charset)
+}
+
+// StringsFrom generates a random string of length characters selected from charset.
+// Returns an error if length <= 0, charset is empty or random generation fails.
+func StringsFrom(length int, charset models.RuneSet) (string, error) {
+	return defaultGenerator.StringsFrom(length, charset)
+}
+
+// StringsGraphemes generates a string of count grapheme clusters, each a base
+// character from charset followed by up to opts.MaxMarks combining marks.
+func StringsGraphemes(count int, charset models.RuneSet, opts GraphemeOptions) (string, error) {
+	return defaultGenerator.StringsGraphemes(count, charset, opts)
+}
+
+// StringsReadable generates a random code of specified length from Crockford's
+// Base32 alphabet, which omits the look-alike letters I, L, O and U.
+func StringsReadable(length int) (string, error) {
+	return defaultGenerator.StringsReadable(length)
+}
+
+// StringsReadableGrouped generates a readable code of length characters split
+// into groups of groupSize joined by sep, e.g. "ABCD-EFGH-JKMN".
+func StringsReadableGrouped(length, groupSize int, sep string) (string, error) {
+	return defaultGenerator.StringsReadableGrouped(length, groupSize, sep)
+}
+
+// Password generates a random password satisfying policy, such as DefaultPasswordPolicy().
+// Every password the policy accepts is equally likely.
+func Password(policy PasswordPolicy) (string, error) {
+	return defaultGenerator.Password(policy)
+}
+
+// Passphrase generates a diceware-style passphrase of words words chosen
+// uniformly from opts.Wordlist (the EFF large wordlist by default).
+// Use PassphraseEntropy to report its strength in bits.
+func Passphrase(words int, opts PassphraseOptions) (string, error) {
+	return defaultGenerator.Passphrase(words, opts)
+}
+
+// FromRegex generates a random string matching the Go regular expression
+// pattern, such as `[A-Z]{3}-\d{4}`. Use CompileRegex to reuse a pattern or
+// configure repetition limits and Unicode handling.
+func FromRegex(pattern string) (string, error) {
+	return defaultGenerator.FromRegex(pattern)
+}
+
+// FromMask generates a random code in a mask format such as "AAA-999-xxx" or
+// "{upper:4}-{digit:6}". Use CompileMask to reuse a mask, add named sets or
+// report its keyspace and entropy.
+func FromMask(mask string) (string, error) {
+	return defaultGenerator.FromMask(mask)
 }
 
 // Byte generates a random byte slice of specified length using cryptographic randomness.
 // Returns an error if length <= 0 or if random generation fails.
 func Byte(length int) ([]byte, error) {
-	if length <= 0 {
-		return nil, fmt.Errorf("invalid length: %d", length)
-	}
-	result := make([]byte, length)
-	_, err := io.ReadFull(crand.Reader, result)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read random bytes: %w", err)
-	}
-	return result, nil
+	return defaultGenerator.Byte(length)
 }
 
 // Base64 generates a random base64-encoded string from random bytes.
 // The length parameter specifies the number of random bytes to generate (not the output string length).
 // The output string will be approximately 4/3 * length characters due to base64 encoding.
 func Base64(length int) (string, error) {
-	result, err := Byte(length)
-	if err != nil {
-		return "", err
-	}
-	return base64.StdEncoding.EncodeToString(result), nil
+	return defaultGenerator.Base64(length)
 }
 
 // Hex generates a random hexadecimal string from random bytes.
 // The length parameter specifies the number of random bytes to generate.
 // The output string will be 2 * length characters (each byte produces 2 hex digits).
 func Hex(length int) (string, error) {
-	result, err := Byte(length)
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("%x", result), nil
+	return defaultGenerator.Hex(length)
 }
 
-// UUID generates a random RFC 4122 version 4 UUID.
+// UUID generates a random RFC 9562 (formerly RFC 4122) version 4 UUID in
+// canonical form. See the uuid package for the UUID type and other versions.
 func UUID() (string, error) {
-	b := make([]byte, 16)
-	_, err := io.ReadFull(crand.Reader, b)
+	return defaultGenerator.UUID()
+}
+
+// UUIDv7 generates a time-ordered RFC 9562 version 7 UUID. UUIDs returned by
+// UUIDv7 sort in creation order within the process; see uuid.V7Generator
+// for other clocks, sources and modes.
+func UUIDv7() (string, error) {
+	id, err := uuid.NewV7()
 	if err != nil {
-		return "", fmt.Errorf("failed to read random bytes: %w", err)
+		return "", err
 	}
-	// Set the version to 4
-	b[6] = (b[6] & 0x0f) | 0x40
-	// Set the variant to RFC 4122
-	b[8] = (b[8] & 0x3f) | 0x80
-	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
-		b[0:4],
-		b[4:6],
-		b[6:8],
-		b[8:10],
-		b[10:]), nil
+	return id.String(), nil
 }
 
 // AllChars generates a random string of specified length using:
 // - uppercase letters (A-Z)
 // - lowercase letters (a-z)
 // - digits (0-9)
-// - special symbols (!@#$%^&*()_+-=[]{}|;:,.<>?)
+// - all 32 printable ASCII symbols (!"#$%&'()*+,-./:;<=>?@[\]^_`{|}~)
 // Returns an error if length <= 0 or if random generation fails.
 func AllChars(length int) (string, error) {
-	if length <= 0 {
-		return "", fmt.Errorf("invalid length: %d", length)
-	}
-	randomInts, err := Random(length, models.Allset)
-	if err != nil {
-		return "", err
-	}
-	return toASCII(randomInts), nil
+	return defaultGenerator.AllChars(length)
+}
+
+// Float64 returns a random float64 in [0, 1) with the full 53-bit significand.
+func Float64() (float64, error) {
+	return defaultGenerator.Float64()
+}
+
+// Float64Open returns a random float64 in the open interval (0, 1).
+func Float64Open() (float64, error) {
+	return defaultGenerator.Float64Open()
+}
+
+// Float64Closed returns a random float64 in the closed interval [0, 1].
+func Float64Closed() (float64, error) {
+	return defaultGenerator.Float64Closed()
+}
+
+// Float64Dense returns a random float64 in [0, 1) drawn from every representable value,
+// each with probability proportional to the gap to its successor.
+func Float64Dense() (float64, error) {
+	return defaultGenerator.Float64Dense()
+}
+
+// Float32 returns a random float32 in [0, 1) with the full 24-bit significand.
+func Float32() (float32, error) {
+	return defaultGenerator.Float32()
+}
+
+// Float32Open returns a random float32 in the open interval (0, 1).
+func Float32Open() (float32, error) {
+	return defaultGenerator.Float32Open()
+}
+
+// Float32Closed returns a random float32 in the closed interval [0, 1].
+func Float32Closed() (float32, error) {
+	return defaultGenerator.Float32Closed()
+}
+
+// Float32Dense returns a random float32 in [0, 1) drawn from every representable value,
+// each with probability proportional to the gap to its successor.
+func Float32Dense() (float32, error) {
+	return defaultGenerator.Float32Dense()
+}
+
+// FloatRange returns a random float64 in the range [min, max) (min inclusive, max exclusive).
+// Returns an error if either bound is not finite or min >= max.
+func FloatRange(min, max float64) (float64, error) {
+	return defaultGenerator.FloatRange(min, max)
+}
+
+// BigInt returns a random *big.Int in the range [0, max) (min inclusive, max exclusive).
+// Returns an error if max is nil or max <= 0.
+func BigInt(max *big.Int) (*big.Int, error) {
+	return defaultGenerator.BigInt(max)
+}
+
+// BigIntRange returns a random *big.Int in the range [min, max) (min inclusive, max exclusive).
+// Returns an error if either bound is nil or min >= max.
+func BigIntRange(min, max *big.Int) (*big.Int, error) {
+	return defaultGenerator.BigIntRange(min, max)
+}
+
+// BigIntBits returns a random number of exactly bits bits (the top bit is always set).
+func BigIntBits(bits int) (*big.Int, error) {
+	return defaultGenerator.BigIntBits(bits)
+}
+
+// BigIntOdd returns a random odd number of exactly bits bits.
+func BigIntOdd(bits int) (*big.Int, error) {
+	return defaultGenerator.BigIntOdd(bits)
+}
+
+// Prime returns a random probable prime of exactly bits bits.
+func Prime(bits int) (*big.Int, error) {
+	return defaultGenerator.Prime(bits)
+}
+
+// SafePrime returns a random probable safe prime p = 2q + 1 of exactly bits bits.
+func SafePrime(bits int) (*big.Int, error) {
+	return defaultGenerator.SafePrime(bits)
 }
 
 // toASCII converts a slice of ASCII integer values to a string.
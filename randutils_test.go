@@ -1,6 +1,7 @@
 package randutils
 
 import (
+	"math"
 	"regexp"
 	"strings"
 	"testing"
@@ -68,6 +69,9 @@ func TestIntRange(t *testing.T) {
 		{"invalid range reversed", 20, 10, true},
 		{"negative range valid", -10, -5, false},
 		{"negative to positive", -5, 5, false},
+		{"full int span", math.MinInt, math.MaxInt, false},
+		{"top of int domain", math.MaxInt - 1, math.MaxInt, false},
+		{"bottom of int domain", math.MinInt, math.MinInt + 1, false},
 	}
 
 	for _, tt := range tests {
@@ -83,6 +87,83 @@ func TestIntRange(t *testing.T) {
 	}
 }
 
+// TestInt64Range tests the Int64Range function at the boundaries of the int64 domain
+func TestInt64Range(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     int64
+		max     int64
+		wantErr bool
+	}{
+		{"valid range", -10, 10, false},
+		{"full int64 span", math.MinInt64, math.MaxInt64, false},
+		{"upper half", 0, math.MaxInt64, false},
+		{"lower half", math.MinInt64, 0, false},
+		{"top single value", math.MaxInt64 - 1, math.MaxInt64, false},
+		{"bottom single value", math.MinInt64, math.MinInt64 + 1, false},
+		{"invalid range equal", math.MaxInt64, math.MaxInt64, true},
+		{"invalid range reversed", math.MaxInt64, math.MinInt64, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Int64Range(tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Int64Range(%d, %d) error = %v, wantErr %v", tt.min, tt.max, err, tt.wantErr)
+			}
+			if !tt.wantErr && (result < tt.min || result >= tt.max) {
+				t.Errorf("Int64Range(%d, %d) result = %d, want result in range [%d, %d)", tt.min, tt.max, result, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+// TestInt64Range_FullSpan tests that the full int64 span yields both signs
+func TestInt64Range_FullSpan(t *testing.T) {
+	var negative, positive bool
+	for i := 0; i < 200 && !(negative && positive); i++ {
+		result, err := Int64Range(math.MinInt64, math.MaxInt64)
+		if err != nil {
+			t.Fatalf("Int64Range() failed: %v", err)
+		}
+		negative = negative || result < 0
+		positive = positive || result > 0
+	}
+	if !negative || !positive {
+		t.Errorf("Int64Range() over the full span produced negative=%v positive=%v", negative, positive)
+	}
+}
+
+// TestUint64Range tests the Uint64Range function at the boundaries of the uint64 domain
+func TestUint64Range(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     uint64
+		max     uint64
+		wantErr bool
+	}{
+		{"valid range", 10, 20, false},
+		{"full uint64 span", 0, math.MaxUint64, false},
+		{"upper half", 1 << 63, math.MaxUint64, false},
+		{"top single value", math.MaxUint64 - 1, math.MaxUint64, false},
+		{"bottom single value", 0, 1, false},
+		{"invalid range equal", math.MaxUint64, math.MaxUint64, true},
+		{"invalid range reversed", 20, 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Uint64Range(tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Uint64Range(%d, %d) error = %v, wantErr %v", tt.min, tt.max, err, tt.wantErr)
+			}
+			if !tt.wantErr && (result < tt.min || result >= tt.max) {
+				t.Errorf("Uint64Range(%d, %d) result = %d, want result in range [%d, %d)", tt.min, tt.max, result, tt.min, tt.max)
+			}
+		})
+	}
+}
+
 // TestRandom tests the Random function
 func TestRandom(t *testing.T) {
 	tests := []struct {
@@ -163,6 +244,71 @@ func TestStrings(t *testing.T) {
 	}
 }
 
+// TestStringsFrom tests the StringsFrom function
+func TestStringsFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		length  int
+		charset models.RuneSet
+		wantErr bool
+	}{
+		{"alphanumeric", 32, models.AlnumChars, false},
+		{"without look-alikes", 64, models.AlnumChars.Subtract(models.NewRuneSet("0O1lI")), false},
+		{"non-ASCII", 16, models.NewRuneSet("αβγδ€"), false},
+		{"single character", 5, models.NewRuneSet("x"), false},
+		{"invalid length zero", 0, models.AlnumChars, true},
+		{"empty charset", 10, models.RuneSet{}, true},
+		{"empty intersection", 10, models.DigitChars.Intersect(models.LowerChars), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := StringsFrom(tt.length, tt.charset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StringsFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if n := len([]rune(result)); n != tt.length {
+				t.Errorf("StringsFrom() length = %d runes, want %d", n, tt.length)
+			}
+			for _, r := range result {
+				if !tt.charset.Contains(r) {
+					t.Errorf("StringsFrom() = %q contains %q, not in charset", result, r)
+				}
+			}
+		})
+	}
+}
+
+// TestRandomFrom_Uniform tests that RandomFrom selects across disjoint ranges uniformly
+func TestRandomFrom_Uniform(t *testing.T) {
+	const samples = 30000
+	charset := models.NewRuneSet("ab").Union(models.NewRuneSet("xyz"))
+	g := NewSeeded(testSeed())
+	result, err := g.RandomFrom(samples, charset)
+	if err != nil {
+		t.Fatalf("RandomFrom() error = %v", err)
+	}
+	counts := make(map[int]int)
+	for _, v := range result {
+		counts[v]++
+	}
+	if len(counts) != 5 {
+		t.Fatalf("RandomFrom() produced %d distinct values, want 5", len(counts))
+	}
+	chi2 := 0.0
+	for _, c := range counts {
+		d := float64(c) - samples/5
+		chi2 += d * d / (samples / 5)
+	}
+	// 0.999 quantile of the chi-square distribution with 4 degrees of freedom
+	if chi2 > 18.47 {
+		t.Errorf("chi-square = %.2f, want <= 18.47 (counts %v)", chi2, counts)
+	}
+}
+
 // TestByte tests the Byte function
 func TestByte(t *testing.T) {
 	tests := []struct {
@@ -317,6 +463,25 @@ func TestUUID_Uniqueness(t *testing.T) {
 	}
 }
 
+// TestUUIDv7 tests that UUIDv7 returns version 7 UUIDs in creation order
+func TestUUIDv7(t *testing.T) {
+	uuidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	prev := ""
+	for range 1000 {
+		result, err := UUIDv7()
+		if err != nil {
+			t.Fatalf("UUIDv7() error = %v", err)
+		}
+		if !uuidRegex.MatchString(result) {
+			t.Fatalf("UUIDv7() returned invalid UUID format: %s", result)
+		}
+		if result <= prev {
+			t.Fatalf("UUIDv7() = %s, not after %s", result, prev)
+		}
+		prev = result
+	}
+}
+
 // TestAllChars tests the AllChars function
 func TestAllChars(t *testing.T) {
 	tests := []struct {
@@ -360,6 +525,24 @@ func TestAllChars(t *testing.T) {
 	}
 }
 
+// TestAllChars_Coverage tests that every character promised by the AllChars documentation can appear
+func TestAllChars_Coverage(t *testing.T) {
+	const promised = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789" +
+		"!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+	if len(promised) != 94 {
+		t.Fatalf("promised set has %d characters, want 94", len(promised))
+	}
+	result, err := NewSeeded(testSeed()).AllChars(10000)
+	if err != nil {
+		t.Fatalf("AllChars() error = %v", err)
+	}
+	for _, ch := range promised {
+		if !strings.ContainsRune(result, ch) {
+			t.Errorf("AllChars() never produced %q in 10000 characters", ch)
+		}
+	}
+}
+
 // TestToASCII tests the toASCII function
 func TestToASCII(t *testing.T) {
 	tests := []struct {
@@ -383,3 +566,48 @@ func TestToASCII(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkInt measures Int with a small bound
+func BenchmarkInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Int(1000); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkIntRange measures IntRange over a range crossing zero
+func BenchmarkIntRange(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := IntRange(-500, 500); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRandom measures Random drawing 64 elements from Charset
+func BenchmarkRandom(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Random(64, models.Charset); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStrings measures Strings generating a 64-char token
+func BenchmarkStrings(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Strings(64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAllChars measures AllChars generating a 64-char password
+func BenchmarkAllChars(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := AllChars(64); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
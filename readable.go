@@ -0,0 +1,40 @@
+package randutils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+// StringsReadable generates a random code of length characters that is easy
+// to read aloud and type. It draws from models.CrockfordChars, Crockford's
+// Base32 alphabet (digits and uppercase letters without I, L, O and U), so
+// 0/O and 1/l/I can never be confused. Each character carries 5 bits.
+func (g *Generator) StringsReadable(length int) (string, error) {
+	return g.StringsFrom(length, models.CrockfordChars)
+}
+
+// StringsReadableGrouped is like StringsReadable but splits the code into
+// groups of groupSize characters joined by sep, such as "ABCD-EFGH-JKMN".
+// length counts code characters only; the last group is shorter when
+// groupSize does not divide length.
+// It returns an error if length <= 0 or groupSize <= 0.
+func (g *Generator) StringsReadableGrouped(length, groupSize int, sep string) (string, error) {
+	if groupSize <= 0 {
+		return "", fmt.Errorf("invalid group size: %d", groupSize)
+	}
+	code, err := g.StringsReadable(length)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.Grow(length + (length-1)/groupSize*len(sep))
+	for i := 0; i < len(code); i += groupSize {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(code[i:min(i+groupSize, len(code))])
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,59 @@
+package randutils
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestStringsReadable tests that StringsReadable only uses Crockford's alphabet
+func TestStringsReadable(t *testing.T) {
+	result, err := NewSeeded(testSeed()).StringsReadable(2000)
+	if err != nil {
+		t.Fatalf("StringsReadable() error = %v", err)
+	}
+	if len(result) != 2000 || !regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]+$`).MatchString(result) {
+		t.Errorf("StringsReadable() returned characters outside Crockford's alphabet: %s", result)
+	}
+	// All 32 symbols should appear in 2000 draws.
+	for _, ch := range "0123456789ABCDEFGHJKMNPQRSTVWXYZ" {
+		if !strings.ContainsRune(result, ch) {
+			t.Errorf("StringsReadable() never produced %q", ch)
+		}
+	}
+	if _, err := StringsReadable(0); err == nil {
+		t.Errorf("StringsReadable(0) error = nil, want error")
+	}
+}
+
+// TestStringsReadableGrouped tests the StringsReadableGrouped function
+func TestStringsReadableGrouped(t *testing.T) {
+	tests := []struct {
+		name      string
+		length    int
+		groupSize int
+		sep       string
+		pattern   string
+		wantErr   bool
+	}{
+		{"three groups of four", 12, 4, "-", `^\w{4}-\w{4}-\w{4}$`, false},
+		{"short last group", 10, 4, "-", `^\w{4}-\w{4}-\w{2}$`, false},
+		{"single group", 4, 8, "-", `^\w{4}$`, false},
+		{"multi-byte separator", 6, 2, " · ", `^\w{2} · \w{2} · \w{2}$`, false},
+		{"empty separator", 9, 3, "", `^\w{9}$`, false},
+		{"zero group size", 12, 0, "-", "", true},
+		{"invalid length", 0, 4, "-", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := StringsReadableGrouped(tt.length, tt.groupSize, tt.sep)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StringsReadableGrouped() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !regexp.MustCompile(tt.pattern).MatchString(result) {
+				t.Errorf("StringsReadableGrouped() = %q, want match for %s", result, tt.pattern)
+			}
+		})
+	}
+}
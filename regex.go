@@ -0,0 +1,374 @@
+package randutils
+
+import (
+	"fmt"
+	"math/big"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chaosoffire/go-randutils/models"
+)
+
+const (
+	// defaultMaxRepeat is the default cap on unbounded repetition in CompileRegex.
+	defaultMaxRepeat = 10
+	// maxRepeatLimit is the largest MaxRepeat accepted, matching the limit
+	// regexp/syntax places on explicit counts such as x{0,1000}.
+	maxRepeatLimit = 1000
+	// maxRegexLength is the largest number of characters a pattern may
+	// generate. It bounds the size of the exact counts CompileRegex computes.
+	maxRegexLength = 4096
+)
+
+// RegexOptions configures CompileRegex. The zero value caps unbounded
+// repetition at 10 and restricts character classes to printable ASCII.
+type RegexOptions struct {
+	// MaxRepeat caps unbounded repetition: x* repeats x at most MaxRepeat
+	// times, x+ likewise, and x{n,} at most max(n, MaxRepeat) times.
+	// Defaults to 10; at most 1000.
+	MaxRepeat int
+	// Unicode lets character classes such as ., \D, [^a-z] and \pL produce
+	// any printable Unicode character. By default they only produce
+	// printable ASCII characters and space. Either way, a class with no
+	// member in that range, such as [\t\n], is used as written. Literals
+	// are always produced as written.
+	Unicode bool
+}
+
+// Regex generates random strings matching a regular expression.
+// It is immutable and safe for concurrent use.
+type Regex struct {
+	pattern string
+	root    *regexNode
+}
+
+// regexOp is the kind of a regexNode.
+type regexOp int
+
+const (
+	regexLiteral   regexOp = iota // lit
+	regexClass                    // one rune from set
+	regexConcat                   // subs in order
+	regexAlternate                // one of subs
+	regexRepeat                   // subs[0] repeated min to max times
+)
+
+// regexNode is a compiled regular expression annotated with the number of
+// ways it can generate a string.
+type regexNode struct {
+	op       regexOp
+	lit      string
+	set      models.RuneSet
+	subs     []*regexNode
+	min, max int
+	// maxLen is the largest number of characters the node can generate.
+	maxLen int
+	// count is the number of ways the node can generate a string: 1 for a
+	// literal, |set| for a class, the product over a concatenation, the sum
+	// over an alternation and the sum of count(sub)^k for k in [min, max]
+	// over a repetition.
+	count *big.Int
+	// weights are the counts of each alternative of an alternation, or of
+	// each repetition count min, min+1, ..., max of a repetition.
+	weights []*big.Int
+}
+
+// CompileRegex parses pattern with Go's regexp/syntax (Perl flags, as
+// regexp.Compile uses) and returns a Regex generating the strings it matches.
+//
+// Every way of matching the pattern is equally likely: alternatives and
+// repetition counts are chosen with probability proportional to the number
+// of strings they lead to. When the pattern is unambiguous, as in
+// [A-Z]{3}-\d{4} or (foo|bar)baz, this makes every matching string equally
+// likely, so longer repetitions dominate: [a-z]{1,3} yields three letters
+// 96% of the time. Anchors such as ^ and $ generate nothing.
+//
+// It returns an error if pattern is invalid, uses \b or \B, matches no
+// string, can generate strings longer than 4096 characters, or
+// opts.MaxRepeat is negative or above 1000.
+func CompileRegex(pattern string, opts RegexOptions) (*Regex, error) {
+	if opts.MaxRepeat < 0 || opts.MaxRepeat > maxRepeatLimit {
+		return nil, fmt.Errorf("invalid max repeat: %d", opts.MaxRepeat)
+	}
+	if opts.MaxRepeat == 0 {
+		opts.MaxRepeat = defaultMaxRepeat
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+	root, err := compileRegexNode(re, opts)
+	if err != nil {
+		return nil, err
+	}
+	if root.count.Sign() == 0 {
+		return nil, fmt.Errorf("regex matches no string: %q", pattern)
+	}
+	return &Regex{pattern: pattern, root: root}, nil
+}
+
+// regexTooLong returns the error for a pattern that can generate more than
+// maxRegexLength characters.
+func regexTooLong() error {
+	return fmt.Errorf("regex can generate strings longer than %d characters", maxRegexLength)
+}
+
+// compileRegexNode converts re into a regexNode tree.
+func compileRegexNode(re *syntax.Regexp, opts RegexOptions) (*regexNode, error) {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText:
+		return literalNode(""), nil
+	case syntax.OpNoMatch:
+		return classNode(models.RuneSet{}), nil
+	case syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return nil, fmt.Errorf("unsupported regex operator: %s", re)
+	case syntax.OpLiteral:
+		if len(re.Rune) > maxRegexLength {
+			return nil, regexTooLong()
+		}
+		if re.Flags&syntax.FoldCase == 0 {
+			return literalNode(string(re.Rune)), nil
+		}
+		subs := make([]*regexNode, len(re.Rune))
+		for i, r := range re.Rune {
+			subs[i] = classNode(restrictClass(foldOrbit(r), opts))
+		}
+		return concatNode(subs), nil
+	case syntax.OpCharClass:
+		return classNode(restrictClass(classRuneSet(re.Rune), opts)), nil
+	case syntax.OpAnyChar:
+		return classNode(restrictClass(classRuneSet([]rune{0, unicode.MaxRune}), opts)), nil
+	case syntax.OpAnyCharNotNL:
+		return classNode(restrictClass(classRuneSet([]rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune}), opts)), nil
+	case syntax.OpCapture:
+		return compileRegexNode(re.Sub[0], opts)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		sub, err := compileRegexNode(re.Sub[0], opts)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			lo, hi = 0, -1
+		case syntax.OpPlus:
+			lo, hi = 1, -1
+		case syntax.OpQuest:
+			lo, hi = 0, 1
+		}
+		if hi < 0 {
+			hi = max(lo, opts.MaxRepeat)
+		}
+		if sub.maxLen > 0 && hi > maxRegexLength/sub.maxLen {
+			return nil, regexTooLong()
+		}
+		return repeatNode(sub, lo, hi), nil
+	case syntax.OpConcat, syntax.OpAlternate:
+		subs := make([]*regexNode, len(re.Sub))
+		for i, s := range re.Sub {
+			sub, err := compileRegexNode(s, opts)
+			if err != nil {
+				return nil, err
+			}
+			subs[i] = sub
+		}
+		if re.Op == syntax.OpConcat {
+			n := concatNode(subs)
+			if n.maxLen > maxRegexLength {
+				return nil, regexTooLong()
+			}
+			return n, nil
+		}
+		return alternateNode(subs), nil
+	}
+	return nil, fmt.Errorf("unsupported regex operator: %s", re)
+}
+
+// literalNode returns a node generating s.
+func literalNode(s string) *regexNode {
+	return &regexNode{op: regexLiteral, lit: s, maxLen: utf8.RuneCountInString(s), count: big.NewInt(1)}
+}
+
+// classNode returns a node generating one member of set.
+func classNode(set models.RuneSet) *regexNode {
+	return &regexNode{op: regexClass, set: set, maxLen: 1, count: big.NewInt(int64(set.Len()))}
+}
+
+// concatNode returns a node generating each of subs in turn.
+func concatNode(subs []*regexNode) *regexNode {
+	n := &regexNode{op: regexConcat, subs: subs, count: big.NewInt(1)}
+	for _, sub := range subs {
+		n.maxLen += sub.maxLen
+		n.count.Mul(n.count, sub.count)
+	}
+	return n
+}
+
+// alternateNode returns a node generating one of subs.
+func alternateNode(subs []*regexNode) *regexNode {
+	n := &regexNode{op: regexAlternate, subs: subs, count: new(big.Int)}
+	for _, sub := range subs {
+		n.maxLen = max(n.maxLen, sub.maxLen)
+		n.weights = append(n.weights, sub.count)
+		n.count.Add(n.count, sub.count)
+	}
+	return n
+}
+
+// repeatNode returns a node generating sub between lo and hi times.
+func repeatNode(sub *regexNode, lo, hi int) *regexNode {
+	n := &regexNode{op: regexRepeat, subs: []*regexNode{sub}, min: lo, max: hi, maxLen: hi * sub.maxLen, count: new(big.Int)}
+	w := new(big.Int).Exp(sub.count, big.NewInt(int64(lo)), nil)
+	for k := lo; k <= hi; k++ {
+		if k > lo {
+			w = new(big.Int).Mul(w, sub.count)
+		}
+		n.weights = append(n.weights, w)
+		n.count.Add(n.count, w)
+	}
+	return n
+}
+
+// classRuneSet returns the set of the inclusive rune pairs of a regexp/syntax class.
+func classRuneSet(pairs []rune) models.RuneSet {
+	table := &unicode.RangeTable{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		table.R32 = append(table.R32, unicode.Range32{Lo: uint32(pairs[i]), Hi: uint32(pairs[i+1]), Stride: 1})
+	}
+	return models.NewRuneSetTable(table)
+}
+
+// foldOrbit returns r and every rune equivalent to it under simple case folding.
+func foldOrbit(r rune) models.RuneSet {
+	runes := []rune{r}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		runes = append(runes, f)
+	}
+	return models.NewRuneSet(string(runes))
+}
+
+var (
+	// asciiRegexChars are the characters classes produce by default.
+	asciiRegexChars = models.PrintableChars.Union(models.NewRuneSet(" "))
+	// unicodeRegexChars are the characters classes produce with RegexOptions.Unicode.
+	unicodeRegexChars = sync.OnceValue(func() models.RuneSet {
+		set := models.NewRuneSet(" ")
+		for _, table := range unicode.PrintRanges {
+			set = set.Union(models.NewRuneSetTable(table))
+		}
+		return set
+	})
+)
+
+// restrictClass limits set to the characters allowed by opts, unless none of
+// its members is allowed.
+func restrictClass(set models.RuneSet, opts RegexOptions) models.RuneSet {
+	allowed := asciiRegexChars
+	if opts.Unicode {
+		allowed = unicodeRegexChars()
+	}
+	if restricted := set.Intersect(allowed); restricted.Len() > 0 {
+		return restricted
+	}
+	return set
+}
+
+// String returns the pattern re was compiled from.
+func (re *Regex) String() string {
+	return re.pattern
+}
+
+// Keyspace returns the number of ways re can generate a string, which is the
+// number of distinct strings it generates when the pattern is unambiguous.
+func (re *Regex) Keyspace() *big.Int {
+	return new(big.Int).Set(re.root.count)
+}
+
+// Generate returns a random string matching the pattern.
+func (re *Regex) Generate() (string, error) {
+	return re.GenerateWith(defaultGenerator)
+}
+
+// GenerateWith is like Generate but draws from g.
+func (re *Regex) GenerateWith(g *Generator) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var sb strings.Builder
+	if err := g.regexLocked(&sb, re.root); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// regexLocked appends a string generated by n to sb.
+// The caller must hold g.mu.
+func (g *Generator) regexLocked(sb *strings.Builder, n *regexNode) error {
+	switch n.op {
+	case regexLiteral:
+		sb.WriteString(n.lit)
+	case regexClass:
+		r, err := g.runeLocked(n.set)
+		if err != nil {
+			return err
+		}
+		sb.WriteRune(r)
+	case regexConcat:
+		for _, sub := range n.subs {
+			if err := g.regexLocked(sb, sub); err != nil {
+				return err
+			}
+		}
+	case regexAlternate:
+		i, err := g.weightedIndexLocked(n.weights, n.count)
+		if err != nil {
+			return err
+		}
+		return g.regexLocked(sb, n.subs[i])
+	case regexRepeat:
+		i, err := g.weightedIndexLocked(n.weights, n.count)
+		if err != nil {
+			return err
+		}
+		for range n.min + i {
+			if err := g.regexLocked(sb, n.subs[0]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// weightedIndexLocked returns i with probability weights[i]/total, where
+// total is the positive sum of weights.
+// The caller must hold g.mu.
+func (g *Generator) weightedIndexLocked(weights []*big.Int, total *big.Int) (int, error) {
+	if len(weights) == 1 {
+		return 0, nil
+	}
+	x, err := g.bigIntnLocked(total)
+	if err != nil {
+		return 0, err
+	}
+	for i, w := range weights {
+		if x.Cmp(w) < 0 {
+			return i, nil
+		}
+		x.Sub(x, w)
+	}
+	panic("randutils: weights do not sum to total")
+}
+
+// FromRegex generates a random string matching pattern with the default
+// RegexOptions. Compile the pattern once with CompileRegex to generate many
+// strings or to change the options.
+// It returns an error if pattern cannot be compiled.
+func (g *Generator) FromRegex(pattern string) (string, error) {
+	re, err := CompileRegex(pattern, RegexOptions{})
+	if err != nil {
+		return "", err
+	}
+	return re.GenerateWith(g)
+}
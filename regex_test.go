@@ -0,0 +1,271 @@
+package randutils
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TestFromRegex_Matches tests that generated strings match their pattern
+func TestFromRegex_Matches(t *testing.T) {
+	patterns := []string{
+		`[A-Z]{3}-\d{4}`,
+		`(foo|bar)baz`,
+		`a+b*c?`,
+		`\w{5,8}`,
+		`(?i)hello`,
+		`[^a-z]{4}`,
+		`.{3}`,
+		`x{2,}`,
+		`^ab$`,
+		`\p{Greek}{3}`,
+		`[\t\n]`,
+		`\.\*\\`,
+		`(a|ab)(c|bcd)`,
+		`[[:xdigit:]]{8}`,
+		`(?:[01]{2}|z){1,3}`,
+		`()`,
+	}
+	g := NewSeeded(testSeed())
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			want := regexp.MustCompile(`^(?:` + pattern + `)$`)
+			for range 200 {
+				s, err := g.FromRegex(pattern)
+				if err != nil {
+					t.Fatalf("FromRegex() error = %v", err)
+				}
+				if !want.MatchString(s) {
+					t.Fatalf("FromRegex() = %q, does not match", s)
+				}
+			}
+		})
+	}
+}
+
+// TestCompileRegex_Uniform tests that every string of an unambiguous pattern is equally likely
+func TestCompileRegex_Uniform(t *testing.T) {
+	tests := []struct {
+		pattern string
+		cells   int
+	}{
+		{`[ab]{1,2}`, 6},
+		{`x|[yz]`, 3},
+		{`(?i)ab`, 4},
+		{`(0|1[01]?)[ab]*`, 12}, // MaxRepeat 1
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			re, err := CompileRegex(tt.pattern, RegexOptions{MaxRepeat: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if re.Keyspace().Int64() != int64(tt.cells) {
+				t.Errorf("Keyspace() = %v, want %d", re.Keyspace(), tt.cells)
+			}
+			samples := 2000 * tt.cells
+			g := NewSeeded(testSeed())
+			counts := make(map[string]int)
+			for range samples {
+				s, err := re.GenerateWith(g)
+				if err != nil {
+					t.Fatalf("GenerateWith() error = %v", err)
+				}
+				counts[s]++
+			}
+			if len(counts) != tt.cells {
+				t.Fatalf("GenerateWith() produced %d distinct strings, want %d", len(counts), tt.cells)
+			}
+			if chi2, limit := chiSquareUniform(counts, tt.cells, samples), chiSquareLimit(tt.cells-1); chi2 > limit {
+				t.Errorf("chi-square = %.2f, want <= %.2f", chi2, limit)
+			}
+		})
+	}
+}
+
+// TestCompileRegex_Keyspace tests the number of strings counted for a pattern
+func TestCompileRegex_Keyspace(t *testing.T) {
+	huge, _ := new(big.Int).SetString("1"+strings.Repeat("0", 200), 10)
+	tests := []struct {
+		pattern   string
+		maxRepeat int
+		want      *big.Int
+	}{
+		{`[A-Z]{3}-\d{4}`, 0, big.NewInt(26 * 26 * 26 * 10000)},
+		{`[a-z]{1,3}`, 0, big.NewInt(26 + 26*26 + 26*26*26)},
+		{`a*`, 5, big.NewInt(6)},
+		{`x{2,}`, 0, big.NewInt(9)},
+		{`x{12,}`, 0, big.NewInt(1)},
+		{`(?i)k`, 0, big.NewInt(2)},
+		{`\d{200}`, 0, huge},
+		{`^$`, 0, big.NewInt(1)},
+	}
+
+	for _, tt := range tests {
+		re, err := CompileRegex(tt.pattern, RegexOptions{MaxRepeat: tt.maxRepeat})
+		if err != nil {
+			t.Fatalf("CompileRegex(%q) error = %v", tt.pattern, err)
+		}
+		if got := re.Keyspace(); got.Cmp(tt.want) != 0 {
+			t.Errorf("CompileRegex(%q).Keyspace() = %v, want %v", tt.pattern, got, tt.want)
+		}
+		if re.String() != tt.pattern {
+			t.Errorf("String() = %q, want %q", re.String(), tt.pattern)
+		}
+	}
+}
+
+// TestCompileRegex_Large tests sampling from a keyspace beyond 64 bits
+func TestCompileRegex_Large(t *testing.T) {
+	re, err := CompileRegex(`(\d{30}|[a-z]{30})`, RegexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewSeeded(testSeed())
+	digits := 0
+	for range 1000 {
+		s, err := re.GenerateWith(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s[0] >= '0' && s[0] <= '9' {
+			digits++
+		}
+	}
+	// 10^30 of the 10^30 + 26^30 strings are digits, about one in 2.8e12
+	if digits != 0 {
+		t.Errorf("GenerateWith() produced %d digit strings, want 0", digits)
+	}
+}
+
+// TestCompileRegex_MaxRepeat tests that unbounded repetition is capped
+func TestCompileRegex_MaxRepeat(t *testing.T) {
+	re, err := CompileRegex(`a*`, RegexOptions{MaxRepeat: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewSeeded(testSeed())
+	seen := make(map[int]bool)
+	for range 200 {
+		s, err := re.GenerateWith(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(s) > 3 {
+			t.Fatalf("GenerateWith() = %q, longer than MaxRepeat", s)
+		}
+		seen[len(s)] = true
+	}
+	if len(seen) != 4 {
+		t.Errorf("GenerateWith() produced lengths %v, want 0 to 3", seen)
+	}
+	if _, err := CompileRegex(`a*`, RegexOptions{MaxRepeat: maxRepeatLimit}); err != nil {
+		t.Errorf("CompileRegex() with MaxRepeat %d error = %v", maxRepeatLimit, err)
+	}
+}
+
+// TestCompileRegex_Unicode tests the character range of classes with and without Unicode
+func TestCompileRegex_Unicode(t *testing.T) {
+	g := NewSeeded(testSeed())
+	ascii, err := CompileRegex(`.{50}`, RegexOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unicodeRe, err := CompileRegex(`.{50}`, RegexOptions{Unicode: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 20 {
+		s, err := ascii.GenerateWith(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range s {
+			if r < ' ' || r > '~' {
+				t.Fatalf("ASCII GenerateWith() = %q, contains %U", s, r)
+			}
+		}
+		u, err := unicodeRe.GenerateWith(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if utf8.RuneCountInString(u) != 50 || !strings.ContainsFunc(u, func(r rune) bool { return r > unicode.MaxASCII }) {
+			t.Fatalf("Unicode GenerateWith() = %q, want 50 runes with non-ASCII", u)
+		}
+		for _, r := range u {
+			if !unicode.IsPrint(r) {
+				t.Fatalf("Unicode GenerateWith() = %q, contains %U", u, r)
+			}
+		}
+	}
+	if ascii.Keyspace().Cmp(unicodeRe.Keyspace()) >= 0 {
+		t.Errorf("ASCII keyspace %v >= Unicode keyspace %v", ascii.Keyspace(), unicodeRe.Keyspace())
+	}
+}
+
+// TestCompileRegex_MaxLength tests that patterns may generate up to maxRegexLength characters
+func TestCompileRegex_MaxLength(t *testing.T) {
+	g := NewSeeded(testSeed())
+	for _, pattern := range []string{`((.*.)*.)*`, `a{1000}b{1000}c{1000}d{1000}e{96}`, `[ab]{1000}[cd]{1000}(e|f{1000})(g|h{1000})i{96}`} {
+		re, err := CompileRegex(pattern, RegexOptions{})
+		if err != nil {
+			t.Fatalf("CompileRegex(%q) error = %v", pattern, err)
+		}
+		s, err := re.GenerateWith(g)
+		if err != nil {
+			t.Fatalf("GenerateWith() error = %v", err)
+		}
+		if n := utf8.RuneCountInString(s); n > maxRegexLength {
+			t.Errorf("GenerateWith() generated %d characters, want at most %d", n, maxRegexLength)
+		}
+	}
+}
+
+// TestCompileRegex_Errors tests that invalid and unsupported patterns are rejected
+func TestCompileRegex_Errors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		opts    RegexOptions
+	}{
+		{`[`, RegexOptions{}},
+		{`a{1001}`, RegexOptions{}},
+		{`\bword\b`, RegexOptions{}},
+		{`a\Bb`, RegexOptions{}},
+		{`[^\x00-\x{10FFFF}]`, RegexOptions{}},
+		{`a`, RegexOptions{MaxRepeat: -1}},
+		{`a*`, RegexOptions{MaxRepeat: 1001}},
+		{`a*`, RegexOptions{MaxRepeat: 1e9}},
+		{`((((((.*.)*.)*.)*.)*.)*.)*`, RegexOptions{}},
+		{`(.*.*)*`, RegexOptions{MaxRepeat: 1000}},
+		{`(.{100}.*)*`, RegexOptions{MaxRepeat: 100}},
+		{`a{1000}b{1000}c{1000}d{1000}e{97}`, RegexOptions{}},
+	}
+
+	for _, tt := range tests {
+		if _, err := CompileRegex(tt.pattern, tt.opts); err == nil {
+			t.Errorf("CompileRegex(%q, %+v) error = nil, want error", tt.pattern, tt.opts)
+		}
+		if tt.opts == (RegexOptions{}) {
+			if _, err := FromRegex(tt.pattern); err == nil {
+				t.Errorf("FromRegex(%q) error = nil, want error", tt.pattern)
+			}
+		}
+	}
+}
+
+// BenchmarkFromRegex measures generation from a compiled pattern
+func BenchmarkFromRegex(b *testing.B) {
+	re, err := CompileRegex(`[A-Z]{3}-\d{4}-(foo|bar)`, RegexOptions{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for range b.N {
+		if _, err := re.Generate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package randutils
+
+import (
+	"bufio"
+	"container/heap"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+)
+
+// ReservoirR returns k items chosen uniformly without replacement from seq,
+// a stream of unknown length, using Vitter's Algorithm R. Every item is
+// kept with equal probability. If seq yields fewer than k items, all of
+// them are returned. The result is in no particular order.
+// It returns an error if k <= 0.
+func ReservoirR[T any](seq iter.Seq[T], k int) ([]T, error) {
+	return ReservoirRWith(defaultGenerator, seq, k)
+}
+
+// ReservoirRWith is like ReservoirR but draws from g.
+func ReservoirRWith[T any](g *Generator, seq iter.Seq[T], k int) ([]T, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid sample size: %d", k)
+	}
+	reservoir := make([]T, 0, k)
+	var err error
+	i := 0
+	for item := range seq {
+		if i < k {
+			reservoir = append(reservoir, item)
+		} else {
+			var j uint64
+			if j, err = g.uint64n(uint64(i) + 1); err != nil {
+				break
+			}
+			if j < uint64(k) {
+				reservoir[j] = item
+			}
+		}
+		i++
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reservoir, nil
+}
+
+// ReservoirL is like ReservoirR but uses Li's Algorithm L, which computes how
+// many items to skip between replacements and so draws only O(k log(n/k))
+// random numbers for a stream of n items instead of one per item.
+func ReservoirL[T any](seq iter.Seq[T], k int) ([]T, error) {
+	return ReservoirLWith(defaultGenerator, seq, k)
+}
+
+// ReservoirLWith is like ReservoirL but draws from g.
+func ReservoirLWith[T any](g *Generator, seq iter.Seq[T], k int) ([]T, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid sample size: %d", k)
+	}
+	reservoir := make([]T, 0, k)
+	var (
+		err  error
+		w    float64 // largest key in the reservoir, as in Li (1994)
+		next int     // index of the next item to take into the reservoir
+	)
+	// advance updates w and next after the reservoir changed.
+	advance := func(i int) error {
+		u, err := g.Float64Open()
+		if err != nil {
+			return err
+		}
+		w *= math.Exp(math.Log(u) / float64(k))
+		if u, err = g.Float64Open(); err != nil {
+			return err
+		}
+		skip := math.Floor(math.Log(u) / math.Log1p(-w))
+		if skip >= float64(math.MaxInt-i-1) {
+			next = math.MaxInt
+		} else {
+			next = i + int(skip) + 1
+		}
+		return nil
+	}
+	i := 0
+	for item := range seq {
+		switch {
+		case i < k:
+			reservoir = append(reservoir, item)
+			if i == k-1 {
+				w = 1
+				err = advance(i)
+			}
+		case i == next:
+			var j uint64
+			if j, err = g.uint64n(uint64(k)); err == nil {
+				reservoir[j] = item
+				err = advance(i)
+			}
+		}
+		if err != nil {
+			break
+		}
+		i++
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reservoir, nil
+}
+
+// ReservoirWeighted returns up to k items chosen without replacement from a
+// stream of (item, weight) pairs, where at each step the probability of
+// selecting an item is proportional to its weight among those remaining.
+// It implements the A-ExpJ algorithm of Efraimidis and Spirakis (2006), which
+// jumps over items instead of drawing a key for each one. Items with zero
+// weight are never selected. It returns an error if k <= 0 or a weight is
+// negative, NaN or infinite.
+func ReservoirWeighted[T any](seq iter.Seq2[T, float64], k int) ([]T, error) {
+	return ReservoirWeightedWith(defaultGenerator, seq, k)
+}
+
+// ReservoirWeightedWith is like ReservoirWeighted but draws from g.
+func ReservoirWeightedWith[T any](g *Generator, seq iter.Seq2[T, float64], k int) ([]T, error) {
+	if k <= 0 {
+		return nil, fmt.Errorf("invalid sample size: %d", k)
+	}
+	// Keys are stored as log(u) / w, the logarithm of the u^(1/w) keys of the
+	// paper, which preserves their order without underflowing.
+	h := &keyHeap[T]{}
+	var (
+		err  error
+		jump float64 // weight to skip before the next insertion
+	)
+	// setJump draws the weight to skip given the current minimum key.
+	setJump := func() error {
+		u, err := g.Float64Open()
+		if err != nil {
+			return err
+		}
+		jump = math.Log(u) / (*h)[0].key
+		return nil
+	}
+	i := 0
+	for item, weight := range seq {
+		if math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+			err = fmt.Errorf("invalid weight at index %d: %v", i, weight)
+			break
+		}
+		i++
+		if weight == 0 {
+			continue
+		}
+		if h.Len() < k {
+			var u float64
+			if u, err = g.Float64Open(); err != nil {
+				break
+			}
+			heap.Push(h, keyed[T]{item: item, key: math.Log(u) / weight})
+			if h.Len() == k {
+				err = setJump()
+			}
+		} else {
+			jump -= weight
+			if jump <= 0 {
+				// The new key is uniform over the keys that beat the minimum.
+				t := math.Exp(weight * (*h)[0].key)
+				var u float64
+				if u, err = g.Float64Open(); err != nil {
+					break
+				}
+				(*h)[0] = keyed[T]{item: item, key: math.Log(t+(1-t)*u) / weight}
+				heap.Fix(h, 0)
+				err = setJump()
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make([]T, h.Len())
+	for j, e := range *h {
+		result[j] = e.item
+	}
+	return result, nil
+}
+
+// ReservoirLines returns k lines chosen uniformly without replacement from r
+// using Algorithm L. Lines are split as by bufio.ScanLines. If r has fewer
+// than k lines, all of them are returned. It returns an error if k <= 0 or
+// if reading r fails.
+func ReservoirLines(r io.Reader, k int) ([]string, error) {
+	return ReservoirLinesWith(defaultGenerator, r, k)
+}
+
+// ReservoirLinesWith is like ReservoirLines but draws from g.
+func ReservoirLinesWith(g *Generator, r io.Reader, k int) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	lines := func(yield func(string) bool) {
+		for scanner.Scan() {
+			if !yield(scanner.Text()) {
+				return
+			}
+		}
+	}
+	result, err := ReservoirLWith(g, lines, k)
+	if err != nil {
+		return nil, err
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read lines: %w", err)
+	}
+	return result, nil
+}
+
+// keyed is an item with its reservoir key.
+type keyed[T any] struct {
+	item T
+	key  float64
+}
+
+// keyHeap is a min-heap of keyed items ordered by key.
+type keyHeap[T any] []keyed[T]
+
+func (h keyHeap[T]) Len() int           { return len(h) }
+func (h keyHeap[T]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h keyHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *keyHeap[T]) Push(x any)        { *h = append(*h, x.(keyed[T])) }
+func (h *keyHeap[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
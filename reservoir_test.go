@@ -0,0 +1,201 @@
+package randutils
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// reservoirFunc is the common signature of ReservoirRWith and ReservoirLWith.
+type reservoirFunc func(*Generator, iter.Seq[int], int) ([]int, error)
+
+// TestReservoir_Uniform tests that every stream item is kept with probability k/n
+func TestReservoir_Uniform(t *testing.T) {
+	const (
+		n       = 20
+		k       = 5
+		samples = 20000
+	)
+	for name, fn := range map[string]reservoirFunc{
+		"R": ReservoirRWith[int],
+		"L": ReservoirLWith[int],
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewSeeded(testSeed())
+			counts := make([]int, n)
+			for range samples {
+				result, err := fn(g, slices.Values(identity(n)), k)
+				if err != nil {
+					t.Fatalf("Reservoir%s() error = %v", name, err)
+				}
+				if len(result) != k {
+					t.Fatalf("Reservoir%s() returned %d items, want %d", name, len(result), k)
+				}
+				for _, v := range result {
+					counts[v]++
+				}
+			}
+			expected := float64(samples * k / n)
+			chi2 := 0.0
+			for _, c := range counts {
+				d := float64(c) - expected
+				chi2 += d * d / expected
+			}
+			// Inclusions are negatively correlated, so the statistic is
+			// conservative; the limit is the 0.999 quantile for 19 degrees of freedom.
+			if chi2 > 43.82 {
+				t.Errorf("Reservoir%s() chi-square = %.2f, want <= 43.82 (counts %v)", name, chi2, counts)
+			}
+		})
+	}
+}
+
+// TestReservoir_Short tests that streams shorter than k are returned whole
+func TestReservoir_Short(t *testing.T) {
+	for name, fn := range map[string]reservoirFunc{
+		"R": ReservoirRWith[int],
+		"L": ReservoirLWith[int],
+	} {
+		result, err := fn(Default(), slices.Values([]int{1, 2, 3}), 5)
+		if err != nil {
+			t.Fatalf("Reservoir%s() error = %v", name, err)
+		}
+		if !slices.Equal(result, []int{1, 2, 3}) {
+			t.Errorf("Reservoir%s() = %v, want [1 2 3]", name, result)
+		}
+		result, err = fn(Default(), slices.Values([]int{}), 5)
+		if err != nil || len(result) != 0 {
+			t.Errorf("Reservoir%s() of empty stream = %v, %v, want empty", name, result, err)
+		}
+	}
+}
+
+// TestReservoir_Errors tests the validation of the reservoir samplers
+func TestReservoir_Errors(t *testing.T) {
+	values := slices.Values([]int{1, 2, 3})
+	weighted := func(w float64) iter.Seq2[int, float64] {
+		return func(yield func(int, float64) bool) {
+			_ = yield(1, 1) && yield(2, w)
+		}
+	}
+	calls := map[string]func() error{
+		"R zero k":          func() error { _, err := ReservoirR(values, 0); return err },
+		"L negative k":      func() error { _, err := ReservoirL(values, -1); return err },
+		"weighted zero k":   func() error { _, err := ReservoirWeighted(weighted(1), 0); return err },
+		"negative weight":   func() error { _, err := ReservoirWeighted(weighted(-1), 1); return err },
+		"NaN weight":        func() error { _, err := ReservoirWeighted(weighted(math.NaN()), 1); return err },
+		"infinite weight":   func() error { _, err := ReservoirWeighted(weighted(math.Inf(1)), 1); return err },
+		"lines zero k":      func() error { _, err := ReservoirLines(strings.NewReader("a\n"), 0); return err },
+		"lines read error":  func() error { _, err := ReservoirLines(errSource{}, 1); return err },
+		"R source error":    func() error { _, err := ReservoirRWith(New(errSource{}), values, 1); return err },
+		"L source error":    func() error { _, err := ReservoirLWith(New(errSource{}), values, 1); return err },
+		"weighted src fail": func() error { _, err := ReservoirWeightedWith(New(errSource{}), weighted(1), 1); return err },
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); err == nil {
+				t.Errorf("%s: error = nil, want error", name)
+			}
+		})
+	}
+}
+
+// TestReservoirWeighted_Proportional tests that a single draw follows the weights
+func TestReservoirWeighted_Proportional(t *testing.T) {
+	const samples = 40000
+	weights := []float64{1, 2, 3, 4, 0, 10}
+	total := 20.0
+	stream := func(yield func(int, float64) bool) {
+		for i, w := range weights {
+			if !yield(i, w) {
+				return
+			}
+		}
+	}
+	g := NewSeeded(testSeed())
+	counts := make([]int, len(weights))
+	for range samples {
+		result, err := ReservoirWeightedWith(g, stream, 1)
+		if err != nil {
+			t.Fatalf("ReservoirWeightedWith() error = %v", err)
+		}
+		counts[result[0]]++
+	}
+	if counts[4] != 0 {
+		t.Errorf("zero-weight item selected %d times", counts[4])
+	}
+	chi2 := 0.0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		expected := samples * w / total
+		d := float64(counts[i]) - expected
+		chi2 += d * d / expected
+	}
+	// 0.999 quantile of the chi-square distribution with 4 degrees of freedom
+	if chi2 > 18.47 {
+		t.Errorf("chi-square = %.2f, want <= 18.47 (counts %v)", chi2, counts)
+	}
+}
+
+// TestReservoirWeighted_Distinct tests that weighted samples have no repeats
+func TestReservoirWeighted_Distinct(t *testing.T) {
+	g := NewSeeded(testSeed())
+	stream := func(yield func(int, float64) bool) {
+		for i := range 1000 {
+			if !yield(i, float64(i%7)) {
+				return
+			}
+		}
+	}
+	for range 50 {
+		result, err := ReservoirWeightedWith(g, stream, 10)
+		if err != nil {
+			t.Fatalf("ReservoirWeightedWith() error = %v", err)
+		}
+		if len(result) != 10 {
+			t.Fatalf("ReservoirWeightedWith() returned %d items, want 10", len(result))
+		}
+		sorted := slices.Clone(result)
+		slices.Sort(sorted)
+		if len(slices.Compact(sorted)) != 10 {
+			t.Fatalf("ReservoirWeightedWith() = %v, contains repeats", result)
+		}
+		for _, v := range result {
+			if v%7 == 0 {
+				t.Fatalf("ReservoirWeightedWith() selected zero-weight item %d", v)
+			}
+		}
+	}
+}
+
+// TestReservoirLines tests the ReservoirLines function
+func TestReservoirLines(t *testing.T) {
+	input := "alpha\nbeta\ngamma\ndelta\nepsilon\n"
+	all := strings.Fields(input)
+	result, err := ReservoirLines(strings.NewReader(input), 3)
+	if err != nil {
+		t.Fatalf("ReservoirLines() error = %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("ReservoirLines() returned %d lines, want 3", len(result))
+	}
+	for _, line := range result {
+		if !slices.Contains(all, line) {
+			t.Errorf("ReservoirLines() returned %q, not an input line", line)
+		}
+	}
+	result, err = ReservoirLines(strings.NewReader(input), 10)
+	if err != nil {
+		t.Fatalf("ReservoirLines() error = %v", err)
+	}
+	if !slices.Equal(result, all) {
+		t.Errorf("ReservoirLines() = %v, want %v", result, all)
+	}
+	if _, err := ReservoirLines(errSource{}, 1); !strings.Contains(err.Error(), "source failure") {
+		t.Errorf("ReservoirLines() error = %v, want wrapped source failure", err)
+	}
+}
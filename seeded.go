@@ -0,0 +1,79 @@
+package randutils
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"sync"
+)
+
+// Seed is the 32-byte key of a seeded Generator.
+// Its String form is the hex encoding accepted by ParseSeed, so it can be
+// printed in test failure messages and fed back in to reproduce a run.
+type Seed [32]byte
+
+// NewSeed returns a fresh Seed read from crypto/rand.
+func NewSeed() (Seed, error) {
+	var seed Seed
+	_, err := io.ReadFull(crand.Reader, seed[:])
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return seed, nil
+}
+
+// ParseSeed parses the 64-character hex form produced by Seed.String.
+func ParseSeed(s string) (Seed, error) {
+	var seed Seed
+	if len(s) != hex.EncodedLen(len(seed)) {
+		return Seed{}, fmt.Errorf("invalid seed length: %d", len(s))
+	}
+	if _, err := hex.Decode(seed[:], []byte(s)); err != nil {
+		return Seed{}, fmt.Errorf("invalid seed: %w", err)
+	}
+	return seed, nil
+}
+
+// String returns the seed as 64 lowercase hex characters.
+func (s Seed) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// NewSeeded returns a deterministic Generator backed by ChaCha8 keyed with seed.
+// Identical seeds produce identical output on every platform and Go version.
+//
+// A seeded Generator is intended for reproducible tests and simulations.
+// Its output is fully determined by the seed, so it must not be used to
+// generate secrets such as passwords, tokens or keys.
+func NewSeeded(seed Seed) *Generator {
+	return New(&seededSource{rng: rand.NewChaCha8(seed)})
+}
+
+// seededSource adapts a ChaCha8 stream to io.Reader.
+// Bytes are emitted little-endian from successive Uint64 outputs.
+type seededSource struct {
+	mu  sync.Mutex
+	rng *rand.ChaCha8
+	buf [8]byte
+	n   int // number of unread bytes at the end of buf
+}
+
+// Read fills p from the ChaCha8 stream. It never fails.
+func (s *seededSource) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := len(p)
+	for len(p) > 0 {
+		if s.n == 0 {
+			binary.LittleEndian.PutUint64(s.buf[:], s.rng.Uint64())
+			s.n = len(s.buf)
+		}
+		c := copy(p, s.buf[len(s.buf)-s.n:])
+		s.n -= c
+		p = p[c:]
+	}
+	return total, nil
+}
@@ -0,0 +1,114 @@
+package randutils
+
+import "fmt"
+
+// Shuffle randomly permutes s in place with the Fisher-Yates algorithm.
+// Every permutation is equally likely.
+func Shuffle[T any](s []T) error {
+	return ShuffleWith(defaultGenerator, s)
+}
+
+// ShuffleWith is like Shuffle but draws from g.
+func ShuffleWith[T any](g *Generator, s []T) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return shuffleLocked(g, s)
+}
+
+// shuffleLocked implements ShuffleWith. The caller must hold g.mu.
+func shuffleLocked[T any](g *Generator, s []T) error {
+	for i := len(s) - 1; i > 0; i-- {
+		j, err := g.uint64nLocked(uint64(i + 1))
+		if err != nil {
+			return err
+		}
+		s[i], s[j] = s[j], s[i]
+	}
+	return nil
+}
+
+// Choice returns a uniformly chosen element of s.
+// It returns an error if s is empty.
+func Choice[T any](s []T) (T, error) {
+	return ChoiceWith(defaultGenerator, s)
+}
+
+// ChoiceWith is like Choice but draws from g.
+func ChoiceWith[T any](g *Generator, s []T) (T, error) {
+	if len(s) == 0 {
+		var zero T
+		return zero, fmt.Errorf("slice is empty")
+	}
+	i, err := g.uint64n(uint64(len(s)))
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return s[i], nil
+}
+
+// Sample returns k distinct elements of s chosen without replacement, in random order.
+// Every ordered selection is equally likely. s is not modified.
+// It returns an error if k <= 0 or k > len(s).
+func Sample[T any](s []T, k int) ([]T, error) {
+	return SampleWith(defaultGenerator, s, k)
+}
+
+// SampleWith is like Sample but draws from g.
+func SampleWith[T any](g *Generator, s []T, k int) ([]T, error) {
+	idx, err := sampleIndices(g, len(s), k)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]T, k)
+	for i, j := range idx {
+		result[i] = s[j]
+	}
+	return result, nil
+}
+
+// sampleIndices returns k distinct indices in [0, n) in random order.
+//
+// Small samples use Robert Floyd's algorithm, which needs only k draws and
+// O(k) memory to produce a uniform k-subset; the subset is then shuffled.
+// Larger samples run a partial Fisher-Yates shuffle over all n indices.
+func sampleIndices(g *Generator, n, k int) ([]int, error) {
+	if k <= 0 || k > n {
+		return nil, fmt.Errorf("invalid sample size: %d", k)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if k <= n/4 {
+		chosen := make(map[int]struct{}, k)
+		idx := make([]int, 0, k)
+		for j := n - k; j < n; j++ {
+			t, err := g.uint64nLocked(uint64(j + 1))
+			if err != nil {
+				return nil, err
+			}
+			v := int(t)
+			if _, ok := chosen[v]; ok {
+				v = j
+			}
+			chosen[v] = struct{}{}
+			idx = append(idx, v)
+		}
+		if err := shuffleLocked(g, idx); err != nil {
+			return nil, err
+		}
+		return idx, nil
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	for i := range k {
+		j, err := g.uint64nLocked(uint64(n - i))
+		if err != nil {
+			return nil, err
+		}
+		r := i + int(j)
+		idx[i], idx[r] = idx[r], idx[i]
+	}
+	return idx[:k], nil
+}
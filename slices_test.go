@@ -0,0 +1,136 @@
+package randutils
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestShuffle tests that Shuffle permutes without losing elements
+func TestShuffle(t *testing.T) {
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if err := Shuffle(s); err != nil {
+		t.Fatalf("Shuffle() error = %v", err)
+	}
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Shuffle() = %v, want a permutation of 0-9", s)
+	}
+	if err := Shuffle([]string{}); err != nil {
+		t.Errorf("Shuffle() of empty slice error = %v", err)
+	}
+}
+
+// TestShuffle_Uniform tests that all 24 permutations of 4 elements are equally likely
+func TestShuffle_Uniform(t *testing.T) {
+	const samples = 48000
+	g := NewSeeded(testSeed())
+	counts := make(map[[4]int]int)
+	for range samples {
+		s := []int{0, 1, 2, 3}
+		if err := ShuffleWith(g, s); err != nil {
+			t.Fatalf("ShuffleWith() error = %v", err)
+		}
+		counts[[4]int(s)]++
+	}
+	if len(counts) != 24 {
+		t.Fatalf("ShuffleWith() produced %d permutations, want 24", len(counts))
+	}
+	chi2 := 0.0
+	for _, c := range counts {
+		d := float64(c) - samples/24
+		chi2 += d * d / (samples / 24)
+	}
+	// 0.999 quantile of the chi-square distribution with 23 degrees of freedom
+	if chi2 > 49.73 {
+		t.Errorf("chi-square = %.2f, want <= 49.73", chi2)
+	}
+}
+
+// TestChoice tests the Choice function
+func TestChoice(t *testing.T) {
+	s := []string{"a", "b", "c"}
+	for range 20 {
+		v, err := Choice(s)
+		if err != nil {
+			t.Fatalf("Choice() error = %v", err)
+		}
+		if !slices.Contains(s, v) {
+			t.Errorf("Choice() = %q, not in %v", v, s)
+		}
+	}
+	if _, err := Choice([]int{}); err == nil {
+		t.Errorf("Choice() of empty slice error = nil, want error")
+	}
+}
+
+// TestSample tests the Sample function with both the Floyd and Fisher-Yates paths
+func TestSample(t *testing.T) {
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i * 10
+	}
+	tests := []struct {
+		name    string
+		k       int
+		wantErr bool
+	}{
+		{"small k uses Floyd", 5, false},
+		{"large k uses Fisher-Yates", 80, false},
+		{"whole slice", 100, false},
+		{"single element", 1, false},
+		{"zero k", 0, true},
+		{"negative k", -1, true},
+		{"k too large", 101, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Sample(s, tt.k)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sample(%d) error = %v, wantErr %v", tt.k, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(result) != tt.k {
+				t.Errorf("Sample(%d) length = %d", tt.k, len(result))
+			}
+			seen := make(map[int]bool)
+			for _, v := range result {
+				if v%10 != 0 || v < 0 || v >= 1000 || seen[v] {
+					t.Errorf("Sample(%d) returned invalid or duplicate element %d", tt.k, v)
+				}
+				seen[v] = true
+			}
+		})
+	}
+}
+
+// TestSample_Uniform tests that ordered pairs drawn by Floyd's algorithm are equally likely
+func TestSample_Uniform(t *testing.T) {
+	const samples = 40000
+	g := NewSeeded(testSeed())
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	counts := make(map[[2]int]int)
+	for range samples {
+		pair, err := SampleWith(g, s, 2)
+		if err != nil {
+			t.Fatalf("SampleWith() error = %v", err)
+		}
+		counts[[2]int(pair)]++
+	}
+	if len(counts) != 90 {
+		t.Fatalf("SampleWith() produced %d ordered pairs, want 90", len(counts))
+	}
+	expected := float64(samples) / 90
+	chi2 := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi2 += d * d / expected
+	}
+	// 0.999 quantile of the chi-square distribution with 89 degrees of freedom
+	if chi2 > 135.98 {
+		t.Errorf("chi-square = %.2f, want <= 135.98", chi2)
+	}
+}
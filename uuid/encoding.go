@@ -0,0 +1,93 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// MarshalText implements encoding.TextMarshaler with the canonical form.
+// It also makes encoding/json write a UUID as a JSON string.
+func (u UUID) MarshalText() ([]byte, error) {
+	buf := make([]byte, 36)
+	u.encode(buf)
+	return buf, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting every form
+// Parse accepts. It also makes encoding/json read a UUID from a JSON string.
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the 16 raw bytes.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return u[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error if data is not 16 bytes long.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	parsed, err := FromBytes(data)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner, so a UUID can be read from text columns, from
+// native UUID columns (which drivers return as text), and from 16-byte
+// binary columns. A NULL value scans as Nil; use NullUUID to tell them apart.
+func (u *UUID) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*u = Nil
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(src))
+	case []byte:
+		if len(src) == Size {
+			return u.UnmarshalBinary(src)
+		}
+		return u.UnmarshalText(src)
+	}
+	return fmt.Errorf("cannot scan %T into UUID", src)
+}
+
+// Value implements driver.Valuer, storing u in its canonical text form,
+// which text and native UUID columns both accept.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// NullUUID is a UUID that may be NULL in a database, like sql.NullString.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullUUID) Scan(src any) error {
+	if src == nil {
+		*n = NullUUID{}
+		return nil
+	}
+	if err := n.UUID.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer, returning nil when n is not Valid.
+func (n NullUUID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.UUID.Value()
+}
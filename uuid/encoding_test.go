@@ -0,0 +1,156 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler     = UUID{}
+	_ encoding.TextUnmarshaler   = (*UUID)(nil)
+	_ encoding.BinaryMarshaler   = UUID{}
+	_ encoding.BinaryUnmarshaler = (*UUID)(nil)
+	_ sql.Scanner                = (*UUID)(nil)
+	_ driver.Valuer              = UUID{}
+	_ sql.Scanner                = (*NullUUID)(nil)
+	_ driver.Valuer              = NullUUID{}
+)
+
+// TestUUID_Text tests the text marshaling round trip
+func TestUUID_Text(t *testing.T) {
+	u := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	text, err := u.MarshalText()
+	if err != nil || string(text) != u.String() {
+		t.Fatalf("MarshalText() = %q, %v", text, err)
+	}
+	var got UUID
+	if err := got.UnmarshalText([]byte("{F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6}")); err != nil || got != u {
+		t.Errorf("UnmarshalText() = %s, %v; want %s", got, err, u)
+	}
+	if err := got.UnmarshalText([]byte("nope")); err == nil {
+		t.Errorf("UnmarshalText(invalid) error = nil, want error")
+	}
+}
+
+// TestUUID_Binary tests the binary marshaling round trip
+func TestUUID_Binary(t *testing.T) {
+	u := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	data, err := u.MarshalBinary()
+	if err != nil || !bytes.Equal(data, u[:]) {
+		t.Fatalf("MarshalBinary() = %x, %v", data, err)
+	}
+	var got UUID
+	if err := got.UnmarshalBinary(data); err != nil || got != u {
+		t.Errorf("UnmarshalBinary() = %s, %v; want %s", got, err, u)
+	}
+	if err := got.UnmarshalBinary(data[:15]); err == nil {
+		t.Errorf("UnmarshalBinary(15 bytes) error = nil, want error")
+	}
+}
+
+// TestUUID_JSON tests that UUIDs are encoded as JSON strings
+func TestUUID_JSON(t *testing.T) {
+	type record struct {
+		ID     UUID         `json:"id"`
+		Parent *UUID        `json:"parent"`
+		Tags   []UUID       `json:"tags"`
+		Keys   map[UUID]int `json:"keys"`
+	}
+	u := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	in := record{ID: u, Tags: []UUID{Nil, Max}, Keys: map[UUID]int{u: 1}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"id":"f81d4fae-7dec-11d0-a765-00a0c91e6bf6","parent":null,` +
+		`"tags":["00000000-0000-0000-0000-000000000000","ffffffff-ffff-ffff-ffff-ffffffffffff"],` +
+		`"keys":{"f81d4fae-7dec-11d0-a765-00a0c91e6bf6":1}}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if out.ID != u || out.Parent != nil || len(out.Tags) != 2 || out.Tags[1] != Max || out.Keys[u] != 1 {
+		t.Errorf("json.Unmarshal() = %+v", out)
+	}
+	if err := json.Unmarshal([]byte(`{"id":"f81d4fae"}`), &out); err == nil {
+		t.Errorf("json.Unmarshal(invalid) error = nil, want error")
+	}
+	if err := json.Unmarshal([]byte(`{"id":42}`), &out); err == nil {
+		t.Errorf("json.Unmarshal(number) error = nil, want error")
+	}
+}
+
+// TestUUID_Scan tests reading UUIDs from database values
+func TestUUID_Scan(t *testing.T) {
+	want := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	tests := []struct {
+		name string
+		src  any
+		want UUID
+	}{
+		{"string", "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", want},
+		{"text bytes", []byte("F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6"), want},
+		{"hex bytes", []byte("f81d4fae7dec11d0a76500a0c91e6bf6"), want},
+		{"binary", want[:], want},
+		{"null", nil, Nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Max
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("Scan() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	var u UUID
+	for _, src := range []any{42, "nope", []byte("short"), 3.5} {
+		if err := u.Scan(src); err == nil {
+			t.Errorf("Scan(%v) error = nil, want error", src)
+		}
+	}
+}
+
+// TestUUID_Value tests writing UUIDs to the database
+func TestUUID_Value(t *testing.T) {
+	u := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	v, err := u.Value()
+	if err != nil || v != "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if !driver.IsValue(v) {
+		t.Errorf("Value() = %T, not a driver.Value", v)
+	}
+}
+
+// TestNullUUID tests NULL handling
+func TestNullUUID(t *testing.T) {
+	u := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	var n NullUUID
+	if err := n.Scan(u.String()); err != nil || !n.Valid || n.UUID != u {
+		t.Errorf("Scan(string) = %+v, %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != u.String() {
+		t.Errorf("Value() = %v, %v", v, err)
+	}
+	if err := n.Scan(nil); err != nil || n.Valid || n.UUID != Nil {
+		t.Errorf("Scan(nil) = %+v, %v", n, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("Value() of NULL = %v, %v", v, err)
+	}
+	if err := n.Scan(42); err == nil || n.Valid {
+		t.Errorf("Scan(42) = %+v, %v; want error", n, err)
+	}
+}
@@ -0,0 +1,53 @@
+package uuid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"hash"
+)
+
+// Namespace IDs for name-based UUIDs (RFC 9562, section 6.6).
+var (
+	// NamespaceDNS is the namespace of fully qualified domain names.
+	NamespaceDNS = MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	// NamespaceURL is the namespace of URLs.
+	NamespaceURL = MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	// NamespaceOID is the namespace of ISO object identifiers.
+	NamespaceOID = MustParse("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	// NamespaceX500 is the namespace of X.500 distinguished names (DER or text).
+	NamespaceX500 = MustParse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+// NewV3 returns the version 3 UUID of name in namespace, built from its MD5
+// hash. The same namespace and name always give the same UUID, which makes
+// it suitable for idempotent resource IDs. Prefer NewV5 for new designs.
+func NewV3(namespace UUID, name string) UUID {
+	return newHashed(md5.New(), 3, namespace, name)
+}
+
+// NewV5 returns the version 5 UUID of name in namespace, built from its
+// SHA-1 hash. The same namespace and name always give the same UUID.
+func NewV5(namespace UUID, name string) UUID {
+	return newHashed(sha1.New(), 5, namespace, name)
+}
+
+// newHashed returns the first 16 bytes of the hash of namespace and name,
+// with the version and variant set.
+func newHashed(h hash.Hash, version byte, namespace UUID, name string) UUID {
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+	var u UUID
+	copy(u[:], h.Sum(nil))
+	u.setVersion(version)
+	return u
+}
+
+// NewV8 returns a version 8 UUID carrying the caller's data in a custom
+// layout (RFC 9562, section 5.8). The version and variant overwrite the
+// high nibble of data[6] and the two high bits of data[8], leaving 122
+// bits of data; everything else about the layout is up to the caller.
+func NewV8(data [Size]byte) UUID {
+	u := UUID(data)
+	u.setVersion(8)
+	return u
+}
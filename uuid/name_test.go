@@ -0,0 +1,75 @@
+package uuid
+
+import "testing"
+
+// TestNewV3V5 tests name-based UUIDs against the RFC 9562 appendix A.2 and A.4 examples
+func TestNewV3V5(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     UUID
+		want    string
+		version int
+	}{
+		{"v3 DNS", NewV3(NamespaceDNS, "www.example.com"), "5df41881-3aed-3515-88a7-2f4a814cf09e", 3},
+		{"v5 DNS", NewV5(NamespaceDNS, "www.example.com"), "2ed6657d-e927-568b-95e1-2665a8aea6a2", 5},
+		{"v5 URL", NewV5(NamespaceURL, "https://www.example.com/"), "", 5},
+		{"v3 empty", NewV3(Nil, ""), "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want != "" && tt.got.String() != tt.want {
+				t.Errorf("got %s, want %s", tt.got, tt.want)
+			}
+			if tt.got.Version() != tt.version || tt.got.Variant() != VariantRFC9562 {
+				t.Errorf("%s has version %d variant %v", tt.got, tt.got.Version(), tt.got.Variant())
+			}
+		})
+	}
+
+	if NewV5(NamespaceDNS, "example.com") != NewV5(NamespaceDNS, "example.com") {
+		t.Errorf("NewV5() is not deterministic")
+	}
+	if NewV5(NamespaceDNS, "example.com") == NewV5(NamespaceURL, "example.com") {
+		t.Errorf("NewV5() ignores the namespace")
+	}
+	if NewV3(NamespaceDNS, "example.com") == NewV5(NamespaceDNS, "example.com") {
+		t.Errorf("NewV3() and NewV5() agree")
+	}
+}
+
+// TestNamespaces tests the predefined namespace IDs
+func TestNamespaces(t *testing.T) {
+	tests := []struct {
+		ns   UUID
+		want string
+	}{
+		{NamespaceDNS, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{NamespaceURL, "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
+		{NamespaceOID, "6ba7b812-9dad-11d1-80b4-00c04fd430c8"},
+		{NamespaceX500, "6ba7b814-9dad-11d1-80b4-00c04fd430c8"},
+	}
+
+	for _, tt := range tests {
+		if tt.ns.String() != tt.want {
+			t.Errorf("namespace = %s, want %s", tt.ns, tt.want)
+		}
+	}
+}
+
+// TestNewV8 tests custom UUIDs against the RFC 9562 appendix B.1 example
+func TestNewV8(t *testing.T) {
+	data := MustParse("2489e9ad-2ee2-0e00-0ec9-32d5f69181c0")
+	u := NewV8(data)
+	if want := "2489e9ad-2ee2-8e00-8ec9-32d5f69181c0"; u.String() != want {
+		t.Errorf("NewV8() = %s, want %s", u, want)
+	}
+
+	u = NewV8(Max)
+	if want := "ffffffff-ffff-8fff-bfff-ffffffffffff"; u.String() != want {
+		t.Errorf("NewV8(Max) = %s, want %s", u, want)
+	}
+	if u.Version() != 8 || u.Variant() != VariantRFC9562 {
+		t.Errorf("NewV8() version %d variant %v", u.Version(), u.Variant())
+	}
+}
@@ -0,0 +1,229 @@
+// Package uuid implements universally unique identifiers as specified by
+// RFC 9562: random (version 4), time-ordered (versions 7 and 6), legacy
+// time-based (version 1), name-based (versions 3 and 5) and custom
+// (version 8) UUIDs, parsing of the common text forms, and the encoding,
+// JSON and database/sql interfaces, so a UUID can be stored as a 16-byte
+// value instead of a string.
+//
+// The package depends only on the standard library. Randomness is read from
+// an io.Reader, crypto/rand by default, so a seeded randutils.Generator can
+// be passed to produce reproducible identifiers in tests:
+//
+//	id, err := uuid.NewV4(randutils.NewSeeded(seed))
+package uuid
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// UUID is a 128-bit universally unique identifier in network byte order.
+type UUID [16]byte
+
+// Size is the length of a UUID in bytes.
+const Size = 16
+
+// hexDigits are the digits of the canonical lowercase text form.
+const hexDigits = "0123456789abcdef"
+
+var (
+	// Nil is the nil UUID, with all bits zero.
+	Nil UUID
+	// Max is the max UUID, with all bits one.
+	Max = UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+)
+
+// urnPrefix starts the URN form of a UUID (RFC 9562, section 4).
+const urnPrefix = "urn:uuid:"
+
+// Parse decodes a UUID in one of these forms, with hexadecimal digits in
+// either case:
+//
+//	f81d4fae-7dec-11d0-a765-00a0c91e6bf6           canonical
+//	{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}         braced (Microsoft)
+//	urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6  URN
+//	f81d4fae7dec11d0a76500a0c91e6bf6               without hyphens
+//
+// It returns an error if s is in none of them. Parse does not check the
+// version or variant; use Version and Variant for that.
+func Parse(s string) (UUID, error) {
+	text := s
+	switch {
+	case len(text) == 38 && text[0] == '{' && text[37] == '}':
+		text = text[1:37]
+	case len(text) == 45 && strings.EqualFold(text[:len(urnPrefix)], urnPrefix):
+		text = text[len(urnPrefix):]
+	case len(text) != 36 && len(text) != 32:
+		return UUID{}, fmt.Errorf("invalid UUID length: %d", len(s))
+	}
+	var u UUID
+	j := 0
+	for i := range u {
+		if len(text) == 36 && (i == 4 || i == 6 || i == 8 || i == 10) {
+			if text[j] != '-' {
+				return UUID{}, fmt.Errorf("invalid UUID format: %q", s)
+			}
+			j++
+		}
+		hi, ok1 := fromHex(text[j])
+		lo, ok2 := fromHex(text[j+1])
+		if !ok1 || !ok2 {
+			return UUID{}, fmt.Errorf("invalid UUID format: %q", s)
+		}
+		u[i] = hi<<4 | lo
+		j += 2
+	}
+	return u, nil
+}
+
+// fromHex returns the value of the hexadecimal digit c.
+func fromHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+// It simplifies initializing variables such as namespace constants.
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic("uuid: " + err.Error())
+	}
+	return u
+}
+
+// FromBytes returns the UUID with the 16 bytes of b.
+// It returns an error if b is not 16 bytes long.
+func FromBytes(b []byte) (UUID, error) {
+	var u UUID
+	if len(b) != Size {
+		return u, fmt.Errorf("invalid UUID length: %d", len(b))
+	}
+	copy(u[:], b)
+	return u, nil
+}
+
+// Version returns the version field of u: 4 for random UUIDs, 7 for
+// time-ordered ones, and so on. It is only meaningful when Variant is
+// VariantRFC9562.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Variant identifies the layout of a UUID.
+type Variant int
+
+const (
+	// VariantNCS is reserved for backward compatibility with NCS UUIDs (0xx).
+	VariantNCS Variant = iota
+	// VariantRFC9562 is the layout of RFC 9562 and RFC 4122 (10x).
+	VariantRFC9562
+	// VariantMicrosoft is reserved for Microsoft GUIDs (110).
+	VariantMicrosoft
+	// VariantFuture is reserved for future definition (111).
+	VariantFuture
+)
+
+// String returns the name of v.
+func (v Variant) String() string {
+	switch v {
+	case VariantNCS:
+		return "NCS"
+	case VariantRFC9562:
+		return "RFC 9562"
+	case VariantMicrosoft:
+		return "Microsoft"
+	case VariantFuture:
+		return "Future"
+	}
+	return fmt.Sprintf("Variant(%d)", int(v))
+}
+
+// Variant returns the variant encoded in the top bits of byte 8 of u.
+// The Nil UUID is VariantNCS and the Max UUID is VariantFuture.
+func (u UUID) Variant() Variant {
+	switch {
+	case u[8]&0x80 == 0:
+		return VariantNCS
+	case u[8]&0x40 == 0:
+		return VariantRFC9562
+	case u[8]&0x20 == 0:
+		return VariantMicrosoft
+	}
+	return VariantFuture
+}
+
+// IsNil reports whether u is the Nil UUID.
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// String returns the canonical form of u, 32 lowercase hexadecimal digits in
+// groups of 8-4-4-4-12 separated by hyphens, such as
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (u UUID) String() string {
+	var buf [36]byte
+	u.encode(buf[:])
+	return string(buf[:])
+}
+
+// URN returns the URN form of u, such as
+// "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (u UUID) URN() string {
+	var buf [len(urnPrefix) + 36]byte
+	copy(buf[:], urnPrefix)
+	u.encode(buf[len(urnPrefix):])
+	return string(buf[:])
+}
+
+// encode writes the canonical form of u to the first 36 bytes of buf.
+func (u UUID) encode(buf []byte) {
+	j := 0
+	for i, b := range u {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			buf[j] = '-'
+			j++
+		}
+		buf[j] = hexDigits[b>>4]
+		buf[j+1] = hexDigits[b&0x0f]
+		j += 2
+	}
+}
+
+// setVersion sets the version field of u to version and the variant field
+// to the RFC 9562 variant (binary 10), the layout shared by every version.
+func (u *UUID) setVersion(version byte) {
+	u[6] = u[6]&0x0f | version<<4
+	u[8] = u[8]&0x3f | 0x80
+}
+
+// readRandom fills b from r, or from crypto/rand when r is nil.
+func readRandom(r io.Reader, b []byte) error {
+	if r == nil {
+		r = crand.Reader
+	}
+	if _, err := io.ReadFull(r, b); err != nil {
+		return fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return nil
+}
+
+// NewV4 returns a random version 4 UUID with 122 random bits read from r
+// (nil selects crypto/rand). It returns an error if r fails.
+func NewV4(r io.Reader) (UUID, error) {
+	var u UUID
+	if err := readRandom(r, u[:]); err != nil {
+		return UUID{}, err
+	}
+	u.setVersion(4)
+	return u, nil
+}
@@ -0,0 +1,206 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// errReader is a random source that always fails
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("source failure")
+}
+
+// mustDecode decodes a UUID written as 32 hex digits or fails the test
+func mustDecode(t *testing.T, s string) UUID {
+	t.Helper()
+	var u UUID
+	if n, err := hex.Decode(u[:], []byte(s)); err != nil || n != Size {
+		t.Fatalf("hex.Decode(%q) = %d, %v", s, n, err)
+	}
+	return u
+}
+
+// TestUUID_String tests the canonical text form
+func TestUUID_String(t *testing.T) {
+	tests := []struct {
+		u    UUID
+		want string
+	}{
+		{UUID{}, "00000000-0000-0000-0000-000000000000"},
+		{mustDecode(t, "f81d4fae7dec11d0a76500a0c91e6bf6"), "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"},
+		{UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "ffffffff-ffff-ffff-ffff-ffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.u.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+// TestNewV4 tests version 4 UUIDs against the RFC 9562 appendix A.3 example
+func TestNewV4(t *testing.T) {
+	random := mustDecode(t, "919108f752d1f3209bacf847db4148a8")
+	u, err := NewV4(bytes.NewReader(random[:]))
+	if err != nil {
+		t.Fatalf("NewV4() error = %v", err)
+	}
+	if want := "919108f7-52d1-4320-9bac-f847db4148a8"; u.String() != want {
+		t.Errorf("NewV4() = %s, want %s", u, want)
+	}
+
+	u, err = NewV4(bytes.NewReader(bytes.Repeat([]byte{0xff}, Size)))
+	if err != nil {
+		t.Fatalf("NewV4() error = %v", err)
+	}
+	if want := "ffffffff-ffff-4fff-bfff-ffffffffffff"; u.String() != want {
+		t.Errorf("NewV4() = %s, want %s", u, want)
+	}
+
+	a, err := NewV4(nil)
+	if err != nil {
+		t.Fatalf("NewV4(nil) error = %v", err)
+	}
+	b, err := NewV4(nil)
+	if err != nil {
+		t.Fatalf("NewV4(nil) error = %v", err)
+	}
+	if a == b || a[6]>>4 != 4 || a[8]>>6 != 2 {
+		t.Errorf("NewV4(nil) = %s, %s", a, b)
+	}
+
+	if _, err := NewV4(errReader{}); err == nil {
+		t.Errorf("NewV4() with failing source error = nil, want error")
+	}
+}
+
+// TestParse tests the accepted text forms
+func TestParse(t *testing.T) {
+	want := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	valid := []string{
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+		"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"URN:UUID:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+	}
+	for _, s := range valid {
+		u, err := Parse(s)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", s, err)
+			continue
+		}
+		if u != want {
+			t.Errorf("Parse(%q) = %s, want %s", s, u, want)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf6a",
+		"f81d4fae_7dec-11d0-a765-00a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a76500-a0c91e6bf6",
+		"g81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bfz",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"(f81d4fae-7dec-11d0-a765-00a0c91e6bf6)",
+		"{f81d4fae7dec11d0a76500a0c91e6bf6}",
+		"urn:uuid:f81d4fae7dec11d0a76500a0c91e6bf6",
+		"uri:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"f81d4fae7dec11d0a76500a0c91e6bf-",
+		"f81d4fae-7dec-11d0-a765+00a0c91e6bf6",
+	}
+	for _, s := range invalid {
+		if u, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %s, want error", s, u)
+		}
+	}
+}
+
+// TestParse_RoundTrip tests that String and URN parse back to the same UUID
+func TestParse_RoundTrip(t *testing.T) {
+	for range 100 {
+		u, err := NewV4(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []string{u.String(), u.URN()} {
+			if got, err := Parse(s); err != nil || got != u {
+				t.Errorf("Parse(%q) = %s, %v; want %s", s, got, err, u)
+			}
+		}
+	}
+}
+
+// TestMustParse tests that MustParse panics on invalid input
+func TestMustParse(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParse() did not panic")
+		}
+	}()
+	MustParse("not-a-uuid")
+}
+
+// TestFromBytes tests conversion from a byte slice
+func TestFromBytes(t *testing.T) {
+	want := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	u, err := FromBytes(want[:])
+	if err != nil || u != want {
+		t.Errorf("FromBytes() = %s, %v; want %s", u, err, want)
+	}
+	for _, n := range []int{0, 15, 17, 36} {
+		if _, err := FromBytes(make([]byte, n)); err == nil {
+			t.Errorf("FromBytes(%d bytes) error = nil, want error", n)
+		}
+	}
+}
+
+// TestUUID_VersionVariant tests the version and variant fields
+func TestUUID_VersionVariant(t *testing.T) {
+	tests := []struct {
+		s       string
+		version int
+		variant Variant
+	}{
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bf6", 1, VariantRFC9562},
+		{"919108f7-52d1-4320-9bac-f847db4148a8", 4, VariantRFC9562},
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7, VariantRFC9562},
+		{"00000000-0000-0000-0000-000000000000", 0, VariantNCS},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 15, VariantFuture},
+		{"3f2504e0-4f89-11d3-9a0c-0305e82c3301", 1, VariantRFC9562},
+		{"6b29fc40-ca47-1067-b31d-00dd010662da", 1, VariantRFC9562},
+		{"00000000-0000-0000-c000-000000000000", 0, VariantMicrosoft},
+		{"00000000-0000-0000-7fff-000000000000", 0, VariantNCS},
+	}
+
+	for _, tt := range tests {
+		u := MustParse(tt.s)
+		if got := u.Version(); got != tt.version {
+			t.Errorf("%s Version() = %d, want %d", tt.s, got, tt.version)
+		}
+		if got := u.Variant(); got != tt.variant {
+			t.Errorf("%s Variant() = %v, want %v", tt.s, got, tt.variant)
+		}
+	}
+	if !Nil.IsNil() || Max.IsNil() || Max.String() != "ffffffff-ffff-ffff-ffff-ffffffffffff" {
+		t.Errorf("Nil = %s, Max = %s", Nil, Max)
+	}
+	if VariantRFC9562.String() != "RFC 9562" || Variant(9).String() != "Variant(9)" {
+		t.Errorf("Variant.String() = %q, %q", VariantRFC9562, Variant(9))
+	}
+}
+
+// TestUUID_URN tests the URN form
+func TestUUID_URN(t *testing.T) {
+	u := MustParse("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	if want := "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"; u.URN() != want {
+		t.Errorf("URN() = %s, want %s", u.URN(), want)
+	}
+}
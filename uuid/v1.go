@@ -0,0 +1,141 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// gregorianOffset is the number of 100-nanosecond intervals from the start
+// of the Gregorian calendar, 1582-10-15, to the Unix epoch.
+const gregorianOffset = 0x01b21dd213814000
+
+const (
+	// maxGregorianTime is the largest 60-bit timestamp of version 1 and 6 UUIDs.
+	maxGregorianTime = 1<<60 - 1
+	// ticksPerSecond is the number of timestamp intervals in a second.
+	ticksPerSecond = 10_000_000
+)
+
+// TimeOptions configures a TimeGenerator. The zero value uses the system
+// clock and crypto/rand.
+type TimeOptions struct {
+	// Clock returns the current time. Defaults to time.Now.
+	Clock func() time.Time
+	// Rand supplies the node ID and initial clock sequence.
+	// Defaults to crypto/rand.Reader.
+	Rand io.Reader
+}
+
+// TimeGenerator creates version 1 and version 6 UUIDs, which carry a
+// 60-bit count of 100-nanosecond intervals since 1582-10-15, a 14-bit clock
+// sequence and a 48-bit node ID. Instead of the host's MAC address, the node
+// ID is random with the multicast bit set (RFC 9562, section 6.10), so UUIDs
+// reveal neither the machine nor its network card. It is safe for
+// concurrent use.
+type TimeGenerator struct {
+	clock func() time.Time
+	rand  io.Reader
+
+	mu       sync.Mutex
+	started  bool
+	clockSeq uint16
+	node     [6]byte
+	floor    uint64 // smallest timestamp of the next UUID
+}
+
+// NewTimeGenerator returns a TimeGenerator configured by opts. Its node ID
+// and clock sequence are drawn on first use.
+func NewTimeGenerator(opts TimeOptions) *TimeGenerator {
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	return &TimeGenerator{clock: opts.Clock, rand: opts.Rand}
+}
+
+// next returns the timestamp, clock sequence and node ID of a new UUID.
+// Timestamps strictly increase, even if the clock goes backwards or two
+// UUIDs fall in the same 100-nanosecond interval; the timestamp then runs
+// ahead of the clock.
+func (g *TimeGenerator) next() (uint64, uint16, [6]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.started {
+		var b [8]byte
+		if err := readRandom(g.rand, b[:]); err != nil {
+			return 0, 0, [6]byte{}, err
+		}
+		g.clockSeq = binary.BigEndian.Uint16(b[:2]) & 0x3fff
+		copy(g.node[:], b[2:])
+		g.node[0] |= 0x01 // multicast bit
+		g.started = true
+	}
+	now := g.clock()
+	sec := now.Unix()
+	if sec < -gregorianOffset/ticksPerSecond || sec > (maxGregorianTime-gregorianOffset)/ticksPerSecond {
+		return 0, 0, [6]byte{}, fmt.Errorf("invalid UUID time: %v", now)
+	}
+	ticks := uint64(sec*ticksPerSecond+gregorianOffset) + uint64(now.Nanosecond()/100)
+	ts := max(ticks, g.floor)
+	if ts > maxGregorianTime {
+		return 0, 0, [6]byte{}, fmt.Errorf("UUID timestamp overflow")
+	}
+	g.floor = ts + 1
+	return ts, g.clockSeq, g.node, nil
+}
+
+// NewV1 returns a version 1 UUID for the current time. Its timestamp is
+// split into fields with the low bits first, so version 1 UUIDs do not sort
+// by time; use NewV6 or NewV7 for sortable UUIDs.
+func (g *TimeGenerator) NewV1() (UUID, error) {
+	ts, clockSeq, node, err := g.next()
+	if err != nil {
+		return UUID{}, err
+	}
+	var u UUID
+	binary.BigEndian.PutUint32(u[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:8], uint16(ts>>48))
+	fillClockSeqNode(&u, clockSeq, node)
+	u.setVersion(1)
+	return u, nil
+}
+
+// NewV6 returns a version 6 UUID for the current time: the fields of a
+// version 1 UUID with the timestamp stored most significant bits first, so
+// UUIDs sort by creation time. It suits systems that already use version 1;
+// RFC 9562 recommends version 7 otherwise.
+func (g *TimeGenerator) NewV6() (UUID, error) {
+	ts, clockSeq, node, err := g.next()
+	if err != nil {
+		return UUID{}, err
+	}
+	var u UUID
+	binary.BigEndian.PutUint64(u[0:8], ts>>12<<16|ts&0x0fff)
+	fillClockSeqNode(&u, clockSeq, node)
+	u.setVersion(6)
+	return u, nil
+}
+
+// fillClockSeqNode stores the clock sequence and node ID in bytes 8-15 of u.
+func fillClockSeqNode(u *UUID, clockSeq uint16, node [6]byte) {
+	binary.BigEndian.PutUint16(u[8:10], clockSeq)
+	copy(u[10:], node[:])
+}
+
+// defaultTime backs NewV1 and NewV6.
+var defaultTime = NewTimeGenerator(TimeOptions{})
+
+// NewV1 returns a version 1 UUID from a shared TimeGenerator using the
+// system clock and crypto/rand.
+func NewV1() (UUID, error) {
+	return defaultTime.NewV1()
+}
+
+// NewV6 returns a version 6 UUID from a shared TimeGenerator using the
+// system clock and crypto/rand.
+func NewV6() (UUID, error) {
+	return defaultTime.NewV6()
+}
@@ -0,0 +1,180 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+)
+
+// rfcTime is the timestamp of the RFC 9562 version 1 and 6 examples,
+// 2022-02-22 14:22:22 at UTC-05:00
+var rfcTime = time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+
+// rfcRandom yields the clock sequence 0x33c8 and node 9f6bdeced846 of the RFC 9562 examples
+func rfcRandom() *bytes.Reader {
+	return bytes.NewReader([]byte{0x33, 0xc8, 0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46})
+}
+
+// gregorianTime returns the 60-bit timestamp of a version 6 UUID
+func gregorianTime(u UUID) uint64 {
+	hi := binary.BigEndian.Uint64(u[0:8])
+	return hi>>16<<12 | hi&0x0fff
+}
+
+// TestTimeGenerator_RFC9562 tests versions 1 and 6 against the RFC 9562 appendix A.1 and A.5 examples
+func TestTimeGenerator_RFC9562(t *testing.T) {
+	clock := func() time.Time { return rfcTime }
+	v1, err := NewTimeGenerator(TimeOptions{Clock: clock, Rand: rfcRandom()}).NewV1()
+	if err != nil {
+		t.Fatalf("NewV1() error = %v", err)
+	}
+	if want := "c232ab00-9414-11ec-b3c8-9f6bdeced846"; v1.String() != want {
+		t.Errorf("NewV1() = %s, want %s", v1, want)
+	}
+	v6, err := NewTimeGenerator(TimeOptions{Clock: clock, Rand: rfcRandom()}).NewV6()
+	if err != nil {
+		t.Fatalf("NewV6() error = %v", err)
+	}
+	if want := "1ec9414c-232a-6b00-b3c8-9f6bdeced846"; v6.String() != want {
+		t.Errorf("NewV6() = %s, want %s", v6, want)
+	}
+}
+
+// TestTimeGenerator_Node tests that the node ID is random with the multicast bit set
+func TestTimeGenerator_Node(t *testing.T) {
+	g := NewTimeGenerator(TimeOptions{Rand: zeroReader{}})
+	a, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := g.NewV6()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [8]byte{0x80, 0x00, 0x01, 0, 0, 0, 0, 0}
+	if [8]byte(a[8:]) != want || [8]byte(b[8:]) != want {
+		t.Errorf("clock sequence and node = %x and %x, want %x", a[8:], b[8:], want)
+	}
+
+	c, err := NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewV6()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c[10]&0x01 == 0 || [8]byte(c[8:]) != [8]byte(d[8:]) {
+		t.Errorf("NewV1() = %s and NewV6() = %s, want the same multicast node", c, d)
+	}
+	if c.Version() != 1 || d.Version() != 6 || c.Variant() != VariantRFC9562 || d.Variant() != VariantRFC9562 {
+		t.Errorf("NewV1() = %s, NewV6() = %s, wrong version or variant", c, d)
+	}
+}
+
+// TestTimeGenerator_Monotonic tests that timestamps increase within a tick and when the clock goes back
+func TestTimeGenerator_Monotonic(t *testing.T) {
+	clock := &fakeClock{t: rfcTime}
+	g := NewTimeGenerator(TimeOptions{Clock: clock.now, Rand: rfcRandom()})
+	first, err := g.NewV6()
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := gregorianTime(first)
+	if base != 0x1ec9414c232ab00 {
+		t.Fatalf("timestamp = %#x, want 0x1ec9414c232ab00", base)
+	}
+	prev := first
+	for i := 1; i <= 100; i++ {
+		if i == 50 {
+			clock.t = clock.t.Add(-time.Second)
+		}
+		u, err := g.NewV6()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gregorianTime(u) != base+uint64(i) || bytes.Compare(u[:], prev[:]) <= 0 {
+			t.Fatalf("NewV6() #%d = %s, want timestamp %#x after %s", i, u, base+uint64(i), prev)
+		}
+		prev = u
+	}
+
+	// Version 1 draws from the same timestamps
+	u, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := uint64(binary.BigEndian.Uint32(u[0:4])) | uint64(binary.BigEndian.Uint16(u[4:6]))<<32 |
+		uint64(binary.BigEndian.Uint16(u[6:8])&0x0fff)<<48; got != base+101 {
+		t.Errorf("NewV1() timestamp = %#x, want %#x", got, base+101)
+	}
+}
+
+// TestTimeGenerator_Concurrent tests that concurrent callers get distinct UUIDs
+func TestTimeGenerator_Concurrent(t *testing.T) {
+	g := NewTimeGenerator(TimeOptions{})
+	const workers, perWorker = 8, 500
+	results := make([][]UUID, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perWorker {
+				newUUID := g.NewV6
+				if i%2 == 1 {
+					newUUID = g.NewV1
+				}
+				u, err := newUUID()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[w] = append(results[w], u)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[UUID]bool)
+	for _, ids := range results {
+		for _, u := range ids {
+			if seen[u] {
+				t.Fatalf("duplicate UUID %s", u)
+			}
+			seen[u] = true
+		}
+	}
+}
+
+// TestTimeGenerator_Errors tests out-of-range clocks and failing sources
+func TestTimeGenerator_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts TimeOptions
+	}{
+		{"before 1582", TimeOptions{Clock: func() time.Time { return time.Date(1582, 10, 14, 0, 0, 0, 0, time.UTC) }}},
+		{"after 5236", TimeOptions{Clock: func() time.Time { return time.Date(5237, 1, 1, 0, 0, 0, 0, time.UTC) }}},
+		{"source failure", TimeOptions{Rand: errReader{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewTimeGenerator(tt.opts)
+			if _, err := g.NewV1(); err == nil {
+				t.Errorf("NewV1() error = nil, want error")
+			}
+			if _, err := g.NewV6(); err == nil {
+				t.Errorf("NewV6() error = nil, want error")
+			}
+		})
+	}
+
+	start := time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)
+	u, err := NewTimeGenerator(TimeOptions{Clock: func() time.Time { return start }}).NewV6()
+	if err != nil || gregorianTime(u) != 0 {
+		t.Errorf("NewV6() at the Gregorian epoch = %s, %v; want timestamp 0", u, err)
+	}
+}
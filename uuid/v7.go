@@ -0,0 +1,120 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// V7Mode selects how a V7Generator fills the 12-bit rand_a field that
+// follows the millisecond timestamp of a version 7 UUID.
+type V7Mode int
+
+const (
+	// V7Counter uses rand_a as a counter (RFC 9562, section 6.2, method 1).
+	// It starts at a random value below 2048 in each millisecond and is
+	// incremented for every further UUID in the same millisecond, so UUIDs
+	// from one generator sort in creation order.
+	V7Counter V7Mode = iota
+	// V7SubMillisecond stores the fraction of the millisecond in rand_a
+	// (RFC 9562, section 6.2, method 3), giving about 244ns precision.
+	// UUIDs from one generator still sort in creation order: a UUID that
+	// would not sort after the previous one takes the next value instead.
+	V7SubMillisecond
+	// V7Random fills rand_a with random bits. UUIDs created in the same
+	// millisecond are in random order.
+	V7Random
+)
+
+// maxUnixMilli is the largest timestamp of the 48-bit unix_ts_ms field.
+const maxUnixMilli = 1<<48 - 1
+
+// V7Options configures a V7Generator. The zero value uses the system clock,
+// crypto/rand and V7Counter.
+type V7Options struct {
+	// Clock returns the current time. Defaults to time.Now.
+	Clock func() time.Time
+	// Rand supplies the random bits. Defaults to crypto/rand.Reader.
+	Rand io.Reader
+	// Mode selects the content of rand_a.
+	Mode V7Mode
+}
+
+// V7Generator creates version 7 UUIDs, whose first 48 bits are a Unix
+// timestamp in milliseconds so that UUIDs sort by creation time and keep
+// B-tree indexes compact. It is safe for concurrent use.
+type V7Generator struct {
+	clock func() time.Time
+	rand  io.Reader
+	mode  V7Mode
+
+	mu   sync.Mutex
+	last uint64 // unix_ts_ms<<12 | rand_a of the previous UUID
+}
+
+// NewV7Generator returns a V7Generator configured by opts.
+func NewV7Generator(opts V7Options) *V7Generator {
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	return &V7Generator{clock: opts.Clock, rand: opts.Rand, mode: opts.Mode}
+}
+
+// New returns a version 7 UUID for the current time. In the V7Counter and
+// V7SubMillisecond modes it sorts after every UUID previously returned by
+// g, even if the clock goes backwards; when more UUIDs are requested in one
+// millisecond than rand_a can count, the timestamp runs ahead of the clock.
+// It returns an error if the clock is before 1970 or after the year 10889,
+// or if reading random bits fails.
+func (g *V7Generator) New() (UUID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.clock()
+	ms := now.UnixMilli()
+	if ms < 0 || ms > maxUnixMilli {
+		return UUID{}, fmt.Errorf("invalid UUIDv7 time: %v", now)
+	}
+	var u UUID
+	if err := readRandom(g.rand, u[6:]); err != nil {
+		return UUID{}, err
+	}
+	randA := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+	ts := uint64(ms) << 12
+	switch g.mode {
+	case V7Counter:
+		// Leave at least 2048 increments before the counter overflows.
+		ts |= randA & 0x07ff
+		if ts <= g.last {
+			ts = g.last + 1
+		}
+	case V7SubMillisecond:
+		ts |= uint64(now.Nanosecond()%int(time.Millisecond)) * 4096 / uint64(time.Millisecond)
+		if ts <= g.last {
+			ts = g.last + 1
+		}
+	case V7Random:
+		ts |= randA
+	default:
+		return UUID{}, fmt.Errorf("invalid UUIDv7 mode: %d", g.mode)
+	}
+	if ts>>12 > maxUnixMilli {
+		return UUID{}, fmt.Errorf("UUIDv7 timestamp overflow")
+	}
+	g.last = max(g.last, ts)
+	// unix_ts_ms fills bytes 0-5 and rand_a the low 12 bits of bytes 6-7.
+	binary.BigEndian.PutUint64(u[:8], ts>>12<<16|ts&0x0fff)
+	u.setVersion(7)
+	return u, nil
+}
+
+// defaultV7 backs NewV7.
+var defaultV7 = NewV7Generator(V7Options{})
+
+// NewV7 returns a version 7 UUID from a shared V7Generator using the system
+// clock, crypto/rand and V7Counter, so UUIDs created by NewV7 anywhere in
+// the process sort in creation order.
+func NewV7() (UUID, error) {
+	return defaultV7.New()
+}
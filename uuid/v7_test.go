@@ -0,0 +1,236 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable clock for V7Options.Clock
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+// zeroReader is a random source that yields only zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// unixMilli returns the 48-bit timestamp of a version 7 UUID
+func unixMilli(u UUID) int64 {
+	var b [8]byte
+	copy(b[2:], u[:6])
+	return int64(binary.BigEndian.Uint64(b[:]))
+}
+
+// randA returns the 12-bit rand_a field of a version 7 UUID
+func randA(u UUID) uint16 {
+	return binary.BigEndian.Uint16(u[6:8]) & 0x0fff
+}
+
+// TestV7Generator_RFC9562 tests the layout against the RFC 9562 appendix A.6 example
+func TestV7Generator_RFC9562(t *testing.T) {
+	random := []byte{0x0c, 0xc3, 0x18, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f}
+	g := NewV7Generator(V7Options{
+		Clock: func() time.Time { return time.UnixMilli(0x017f22e279b0) },
+		Rand:  bytes.NewReader(random),
+		Mode:  V7Random,
+	})
+	u, err := g.New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if want := "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"; u.String() != want {
+		t.Errorf("New() = %s, want %s", u, want)
+	}
+}
+
+// TestV7Generator_Counter tests that the counter orders UUIDs within a millisecond
+func TestV7Generator_Counter(t *testing.T) {
+	clock := &fakeClock{t: time.UnixMilli(1_700_000_000_000)}
+	g := NewV7Generator(V7Options{Clock: clock.now, Rand: zeroReader{}})
+	for i := range 5000 {
+		u, err := g.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		// 4096 counter values fit in the first millisecond, then the
+		// timestamp runs ahead of the clock
+		wantMs, wantA := int64(1_700_000_000_000+i/4096), uint16(i%4096)
+		if unixMilli(u) != wantMs || randA(u) != wantA {
+			t.Fatalf("New() #%d = %s, want ms %d counter %d", i, u, wantMs, wantA)
+		}
+		if u[6]>>4 != 7 || u[8]>>6 != 2 {
+			t.Fatalf("New() = %s, wrong version or variant", u)
+		}
+	}
+
+	// Once the clock passes the borrowed timestamp the counter restarts
+	clock.t = time.UnixMilli(1_700_000_000_005)
+	u, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unixMilli(u) != 1_700_000_000_005 || randA(u) != 0 {
+		t.Errorf("New() after clock advance = %s", u)
+	}
+}
+
+// TestV7Generator_CounterStart tests that the counter starts below 2048
+func TestV7Generator_CounterStart(t *testing.T) {
+	clock := &fakeClock{t: time.UnixMilli(1_700_000_000_000)}
+	g := NewV7Generator(V7Options{Clock: clock.now, Rand: bytes.NewReader(bytes.Repeat([]byte{0xff}, 100))})
+	for i := range 5 {
+		clock.t = clock.t.Add(time.Millisecond)
+		u, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if randA(u) != 0x7ff {
+			t.Errorf("New() #%d rand_a = %#x, want 0x7ff", i, randA(u))
+		}
+	}
+}
+
+// TestV7Generator_SubMillisecond tests that rand_a holds the millisecond fraction
+func TestV7Generator_SubMillisecond(t *testing.T) {
+	base := time.UnixMilli(1_700_000_000_000)
+	clock := &fakeClock{}
+	g := NewV7Generator(V7Options{Clock: clock.now, Rand: zeroReader{}, Mode: V7SubMillisecond})
+	tests := []struct {
+		offset time.Duration
+		wantMs int64
+		wantA  uint16
+	}{
+		{0, 1_700_000_000_000, 0},
+		{500 * time.Microsecond, 1_700_000_000_000, 2048},
+		{999_999 * time.Nanosecond, 1_700_000_000_000, 4095},
+		{1*time.Millisecond + 250*time.Microsecond, 1_700_000_000_001, 1024},
+		// Repeated and earlier times still sort after the previous UUID
+		{1*time.Millisecond + 250*time.Microsecond, 1_700_000_000_001, 1025},
+		{0, 1_700_000_000_001, 1026},
+	}
+
+	for _, tt := range tests {
+		clock.t = base.Add(tt.offset)
+		u, err := g.New()
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if unixMilli(u) != tt.wantMs || randA(u) != tt.wantA {
+			t.Errorf("New() at +%v = ms %d rand_a %d, want ms %d rand_a %d", tt.offset, unixMilli(u), randA(u), tt.wantMs, tt.wantA)
+		}
+	}
+}
+
+// TestV7Generator_ClockBackwards tests that ordering survives a clock step backwards
+func TestV7Generator_ClockBackwards(t *testing.T) {
+	clock := &fakeClock{t: time.UnixMilli(1_700_000_001_000)}
+	g := NewV7Generator(V7Options{Clock: clock.now})
+	prev, err := g.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.t = clock.t.Add(-time.Second)
+	for range 100 {
+		u, err := g.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.String() <= prev.String() {
+			t.Fatalf("New() = %s, not after %s", u, prev)
+		}
+		prev = u
+	}
+}
+
+// TestV7Generator_Concurrent tests that concurrent callers get distinct, ordered UUIDs
+func TestV7Generator_Concurrent(t *testing.T) {
+	g := NewV7Generator(V7Options{})
+	const workers, perWorker = 8, 500
+	results := make([][]UUID, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				u, err := g.New()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[w] = append(results[w], u)
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[UUID]bool)
+	for _, ids := range results {
+		for i, u := range ids {
+			if seen[u] {
+				t.Fatalf("New() returned %s twice", u)
+			}
+			seen[u] = true
+			if i > 0 && bytes.Compare(u[:], ids[i-1][:]) <= 0 {
+				t.Fatalf("New() = %s, not after %s from the same goroutine", u, ids[i-1])
+			}
+		}
+	}
+}
+
+// TestV7Generator_Errors tests invalid clocks, modes and failing sources
+func TestV7Generator_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts V7Options
+	}{
+		{"before epoch", V7Options{Clock: func() time.Time { return time.UnixMilli(-1) }}},
+		{"after year 10889", V7Options{Clock: func() time.Time { return time.UnixMilli(1 << 48) }}},
+		{"unknown mode", V7Options{Mode: V7Mode(42)}},
+		{"source failure", V7Options{Rand: errReader{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewV7Generator(tt.opts).New(); err == nil {
+				t.Errorf("New() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestNewV7 tests the shared generator
+func TestNewV7(t *testing.T) {
+	before := time.Now().UnixMilli()
+	u, err := NewV7()
+	if err != nil {
+		t.Fatalf("NewV7() error = %v", err)
+	}
+	after := time.Now().UnixMilli()
+	if ms := unixMilli(u); ms < before || ms > after+1 {
+		t.Errorf("NewV7() timestamp = %d, want in [%d, %d]", ms, before, after)
+	}
+	if u[6]>>4 != 7 || u[8]>>6 != 2 {
+		t.Errorf("NewV7() = %s, wrong version or variant", u)
+	}
+}
+
+// BenchmarkNewV7 measures version 7 generation
+func BenchmarkNewV7(b *testing.B) {
+	for range b.N {
+		if _, err := NewV7(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
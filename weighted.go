@@ -0,0 +1,128 @@
+package randutils
+
+import (
+	"fmt"
+	"math"
+)
+
+// Weight is the set of types accepted as weights by NewWeightedChoice.
+type Weight interface {
+	Integer | Float
+}
+
+// WeightedChoice selects items with probability proportional to their weights.
+// It precomputes a Walker/Vose alias table, so each selection costs one bounded
+// integer and one float draw regardless of the number of items. A WeightedChoice
+// is immutable after construction and safe for concurrent use.
+type WeightedChoice[T any] struct {
+	items []T
+	prob  []float64
+	alias []int
+}
+
+// NewWeightedChoice builds an alias table over items with the matching weights.
+// Items with zero weight are never selected. It returns an error if the slices
+// are empty or differ in length, if any weight is negative, NaN or infinite,
+// or if all weights are zero.
+func NewWeightedChoice[T any, W Weight](items []T, weights []W) (*WeightedChoice[T], error) {
+	n := len(items)
+	if n == 0 {
+		return nil, fmt.Errorf("items is empty")
+	}
+	if len(weights) != n {
+		return nil, fmt.Errorf("invalid weights length: %d, want %d", len(weights), n)
+	}
+	// Normalise by the largest weight first so the sum cannot overflow.
+	w := make([]float64, n)
+	largest, heaviest := 0.0, 0
+	for i, v := range weights {
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) || f < 0 {
+			return nil, fmt.Errorf("invalid weight at index %d: %v", i, f)
+		}
+		w[i] = f
+		if f > largest {
+			largest, heaviest = f, i
+		}
+	}
+	if largest == 0 {
+		return nil, fmt.Errorf("weights sum to zero")
+	}
+	total := 0.0
+	for i := range w {
+		w[i] /= largest
+		total += w[i]
+	}
+
+	c := &WeightedChoice[T]{
+		items: append([]T(nil), items...),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	// Vose's alias method: scale weights to mean 1, then pair each column
+	// below 1 with a column above 1 that tops it up.
+	scaled := make([]float64, n)
+	var small, large []int
+	for i := range w {
+		scaled[i] = w[i] * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l := small[len(small)-1]
+		small = small[:len(small)-1]
+		h := large[len(large)-1]
+		large = large[:len(large)-1]
+		c.prob[l] = scaled[l]
+		c.alias[l] = h
+		scaled[h] = (scaled[h] + scaled[l]) - 1
+		if scaled[h] < 1 {
+			small = append(small, h)
+		} else {
+			large = append(large, h)
+		}
+	}
+	// Leftover columns are full up to rounding error. A zero-weight column
+	// left over by rounding must still never be selected.
+	for _, i := range append(small, large...) {
+		c.prob[i] = 1
+		c.alias[i] = i
+		if w[i] == 0 {
+			c.prob[i] = 0
+			c.alias[i] = heaviest
+		}
+	}
+	return c, nil
+}
+
+// Len returns the number of items in the table.
+func (c *WeightedChoice[T]) Len() int {
+	return len(c.items)
+}
+
+// Pick returns a random item chosen with probability proportional to its weight.
+func (c *WeightedChoice[T]) Pick() (T, error) {
+	return c.PickWith(defaultGenerator)
+}
+
+// PickWith is like Pick but draws from g.
+func (c *WeightedChoice[T]) PickWith(g *Generator) (T, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var zero T
+	i, err := g.uint64nLocked(uint64(len(c.items)))
+	if err != nil {
+		return zero, err
+	}
+	x, err := g.uint64Locked()
+	if err != nil {
+		return zero, err
+	}
+	if float64(x>>11)*0x1p-53 < c.prob[i] {
+		return c.items[i], nil
+	}
+	return c.items[c.alias[i]], nil
+}
@@ -0,0 +1,105 @@
+package randutils
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNewWeightedChoice tests weight validation
+func TestNewWeightedChoice(t *testing.T) {
+	items := []string{"a", "b", "c"}
+	tests := []struct {
+		name    string
+		items   []string
+		weights []float64
+		wantErr bool
+	}{
+		{"valid", items, []float64{1, 2, 3}, false},
+		{"zero weight allowed", items, []float64{0, 2, 3}, false},
+		{"huge weights", items, []float64{math.MaxFloat64, math.MaxFloat64, 1}, false},
+		{"empty items", nil, nil, true},
+		{"length mismatch", items, []float64{1, 2}, true},
+		{"negative weight", items, []float64{1, -2, 3}, true},
+		{"NaN weight", items, []float64{1, math.NaN(), 3}, true},
+		{"infinite weight", items, []float64{1, math.Inf(1), 3}, true},
+		{"all zero", items, []float64{0, 0, 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewWeightedChoice(tt.items, tt.weights)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewWeightedChoice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && c.Len() != len(tt.items) {
+				t.Errorf("Len() = %d, want %d", c.Len(), len(tt.items))
+			}
+		})
+	}
+}
+
+// TestWeightedChoice_Distribution runs a chi-square test of selections against the weights
+func TestWeightedChoice_Distribution(t *testing.T) {
+	const samples = 100000
+	weights := []uint16{1, 2, 3, 4, 0, 10, 5}
+	items := []int{0, 1, 2, 3, 4, 5, 6}
+	c, err := NewWeightedChoice(items, weights)
+	if err != nil {
+		t.Fatalf("NewWeightedChoice() error = %v", err)
+	}
+	g := NewSeeded(testSeed())
+	counts := make([]int, len(items))
+	for range samples {
+		v, err := c.PickWith(g)
+		if err != nil {
+			t.Fatalf("PickWith() error = %v", err)
+		}
+		counts[v]++
+	}
+	if counts[4] != 0 {
+		t.Errorf("zero-weight item picked %d times", counts[4])
+	}
+	chi2 := 0.0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		expected := float64(samples) * float64(w) / 25
+		d := float64(counts[i]) - expected
+		chi2 += d * d / expected
+	}
+	// 0.999 quantile of the chi-square distribution with 5 degrees of freedom
+	if chi2 > 20.52 {
+		t.Errorf("chi-square = %.2f, want <= 20.52 (counts %v)", chi2, counts)
+	}
+}
+
+// TestWeightedChoice_Single tests that a single positive weight is always selected
+func TestWeightedChoice_Single(t *testing.T) {
+	c, err := NewWeightedChoice([]string{"x", "y", "z"}, []float32{0, 0.25, 0})
+	if err != nil {
+		t.Fatalf("NewWeightedChoice() error = %v", err)
+	}
+	for range 100 {
+		v, err := c.Pick()
+		if err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+		if v != "y" {
+			t.Fatalf("Pick() = %q, want %q", v, "y")
+		}
+	}
+}
+
+// TestWeightedChoice_Copy tests that the table is not affected by later changes to the input
+func TestWeightedChoice_Copy(t *testing.T) {
+	items := []string{"keep"}
+	c, err := NewWeightedChoice(items, []int{1})
+	if err != nil {
+		t.Fatalf("NewWeightedChoice() error = %v", err)
+	}
+	items[0] = "changed"
+	if v, _ := c.Pick(); v != "keep" {
+		t.Errorf("Pick() = %q, want %q", v, "keep")
+	}
+}